@@ -0,0 +1,118 @@
+// Package apiclient sends requests to CacheFly API endpoints that the SDK
+// does not cover. Requests go through the same HTTP client as the SDK, so
+// they share its retry, limit, trace and read-only handling.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+// Client sends authenticated requests to the CacheFly API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// New returns a Client for the API at baseURL.
+func New(httpClient *http.Client, baseURL, token string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// Get requests path and decodes the JSON response into out. If out is a
+// *[]byte, the raw response body is stored instead.
+func (c *Client) Get(ctx context.Context, path string, out interface{}) error {
+	body, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return decode(path, body, out)
+}
+
+// Put sends in as the JSON body of a PUT request for path and decodes the
+// response into out, which may be nil.
+func (c *Client) Put(ctx context.Context, path string, in, out interface{}) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("could not encode request for %s: %w", path, err)
+	}
+
+	body, err := c.do(ctx, http.MethodPut, path, payload)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	return decode(path, body, out)
+}
+
+// Delete sends a DELETE request for path.
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.do(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// decode stores body in out. If out is a *[]byte, the raw body is stored.
+func decode(path string, body []byte, out interface{}) error {
+	if raw, ok := out.(*[]byte); ok {
+		*raw = body
+		return nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("could not decode response from %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// do performs an authenticated request. Failed requests are reported as an
+// *apierrors.Error carrying the response's status code and request ID.
+func (c *Client) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, apierrors.FromResponse(resp, body)
+	}
+
+	return body, nil
+}
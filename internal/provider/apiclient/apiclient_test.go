@@ -0,0 +1,110 @@
+package apiclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+func TestClient_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/api/2.6/services/svc-1", r.URL.Path)
+		_, _ = w.Write([]byte(`{"_id":"svc-1","uniqueName":"example"}`))
+	}))
+	defer server.Close()
+
+	client := apiclient.New(server.Client(), server.URL+"/api/2.6/", "test-token")
+
+	var service struct {
+		ID         string `json:"_id"`
+		UniqueName string `json:"uniqueName"`
+	}
+	if assert.NoError(t, client.Get(context.Background(), "/services/svc-1", &service)) {
+		assert.Equal(t, "svc-1", service.ID)
+		assert.Equal(t, "example", service.UniqueName)
+	}
+
+	var raw []byte
+	if assert.NoError(t, client.Get(context.Background(), "/services/svc-1", &raw)) {
+		assert.JSONEq(t, `{"_id":"svc-1","uniqueName":"example"}`, string(raw))
+	}
+}
+
+func TestClient_Put(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "/services/svc-1", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"configurationMode":"API_RULES_AND_OPTIONS"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"_id":"svc-1","configurationMode":"API_RULES_AND_OPTIONS"}`))
+	}))
+	defer server.Close()
+
+	client := apiclient.New(server.Client(), server.URL, "test-token")
+
+	var service struct {
+		ConfigurationMode string `json:"configurationMode"`
+	}
+	request := map[string]string{"configurationMode": "API_RULES_AND_OPTIONS"}
+	if assert.NoError(t, client.Put(context.Background(), "/services/svc-1", request, &service)) {
+		assert.Equal(t, "API_RULES_AND_OPTIONS", service.ConfigurationMode)
+	}
+
+	assert.NoError(t, client.Put(context.Background(), "/services/svc-1", request, nil))
+}
+
+func TestClient_Delete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		switch r.URL.Path {
+		case "/services/svc-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_, _ = w.Write([]byte(`{"message":"Method Not Allowed"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := apiclient.New(server.Client(), server.URL, "test-token")
+
+	assert.NoError(t, client.Delete(context.Background(), "/services/svc-1"))
+
+	err := client.Delete(context.Background(), "/services/svc-2")
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusMethodNotAllowed, apierrors.Classify(err).StatusCode)
+		assert.Contains(t, err.Error(), "Method Not Allowed")
+	}
+}
+
+func TestReferenceID(t *testing.T) {
+	tests := map[string]string{
+		``:                                "",
+		`null`:                            "",
+		`"tls-1"`:                         "tls-1",
+		`{"_id":"tls-1","name":"Modern"}`: "tls-1",
+	}
+	for data, want := range tests {
+		id, err := apiclient.ReferenceID(json.RawMessage(data))
+		if assert.NoError(t, err, data) {
+			assert.Equal(t, want, id, data)
+		}
+	}
+
+	_, err := apiclient.ReferenceID(json.RawMessage(`42`))
+	assert.ErrorContains(t, err, "expected an ID or an object")
+}
@@ -0,0 +1,29 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReferenceID returns the ID of a reference in a response, such as the TLS
+// profile or delivery region of a service. The API returns references as an
+// ID or, in deep responses, as the referenced object. A missing or null
+// reference has an empty ID.
+func ReferenceID(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		return id, nil
+	}
+
+	var object struct {
+		ID string `json:"_id"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", fmt.Errorf("expected an ID or an object, got %s", data)
+	}
+	return object.ID, nil
+}
@@ -0,0 +1,269 @@
+// Package apierrors classifies errors returned by the CacheFly SDK so that
+// resources and data sources handle missing objects, throttling and
+// credential problems the same way.
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+)
+
+// Kind describes the category of a failed API call.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindNotFound
+	KindConflict
+	KindRateLimited
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindUnavailable
+	KindReadOnly
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "NotFound"
+	case KindConflict:
+		return "Conflict"
+	case KindRateLimited:
+		return "RateLimited"
+	case KindValidation:
+		return "Validation"
+	case KindUnauthorized:
+		return "Unauthorized"
+	case KindForbidden:
+		return "Forbidden"
+	case KindUnavailable:
+		return "Unavailable"
+	case KindReadOnly:
+		return "ReadOnly"
+	default:
+		return "Unknown"
+	}
+}
+
+// ErrReadOnly is returned for requests that would change CacheFly objects
+// while the provider is configured with read_only = true. Such requests are
+// never sent.
+var ErrReadOnly = errors.New("the CacheFly provider is in read-only mode")
+
+// Error is a classified CacheFly API error.
+type Error struct {
+	Kind       Kind
+	StatusCode int
+	RequestID  string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// sdkErrorPattern matches the "API error <status>: <body>" form in which the
+// SDK reports failed calls, either on its own or after the ": " of a wrapping
+// error. Only this form is trusted: other numbers in an error message, such
+// as a port, are never taken as a status code.
+var sdkErrorPattern = regexp.MustCompile(`(?s)(?:^|: )API error (\d{3}): ?(.*)$`)
+
+// requestIDHeaders are checked in order for the ID the API assigned to a
+// request.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id"}
+
+// requestIDFields are the fields of an error body that may hold the request ID.
+var requestIDFields = []string{"requestId", "request_id", "requestID"}
+
+// FromResponse builds the error for a failed API response whose body has
+// already been read. The status code and request ID are taken from the
+// response itself.
+func FromResponse(resp *http.Response, body []byte) *Error {
+	return &Error{
+		Kind:       KindForStatus(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		RequestID:  RequestID(resp.Header, body),
+		Err:        fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+	}
+}
+
+// RequestID returns the ID the API assigned to a request, from the response
+// headers or, failing that, from a JSON error body.
+func RequestID(header http.Header, body []byte) string {
+	for _, name := range requestIDHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return requestIDFromBody(body)
+}
+
+func requestIDFromBody(body []byte) string {
+	var fields map[string]interface{}
+	if len(body) == 0 || json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+	for _, name := range requestIDFields {
+		if value, ok := fields[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// KindForStatus maps an HTTP status code to an error kind.
+func KindForStatus(statusCode int) Kind {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindConflict
+	case http.StatusTooManyRequests:
+		return KindRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindValidation
+	case http.StatusUnauthorized:
+		return KindUnauthorized
+	case http.StatusForbidden:
+		return KindForbidden
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return KindUnavailable
+	default:
+		return KindUnknown
+	}
+}
+
+// Classify inspects err and returns it as an *Error. It returns nil when err
+// is nil.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	classified = &Error{Err: err}
+
+	var validationErr api.ServiceOptionsValidationError
+	var validationErrPtr *api.ServiceOptionsValidationError
+	var statusErr interface{ StatusCode() int }
+	var requestIDErr interface{ RequestID() string }
+
+	switch {
+	case errors.Is(err, ErrReadOnly) || strings.Contains(err.Error(), ErrReadOnly.Error()):
+		// The SDK may flatten transport errors into a string.
+		classified.Kind = KindReadOnly
+	case errors.As(err, &validationErr), errors.As(err, &validationErrPtr):
+		classified.Kind = KindValidation
+		classified.StatusCode = http.StatusBadRequest
+	case errors.As(err, &statusErr):
+		classified.StatusCode = statusErr.StatusCode()
+	default:
+		classified.StatusCode, classified.RequestID = fromSDKError(err)
+	}
+
+	if errors.As(err, &requestIDErr) {
+		classified.RequestID = requestIDErr.RequestID()
+	}
+
+	if classified.Kind == KindUnknown {
+		classified.Kind = KindForStatus(classified.StatusCode)
+	}
+
+	return classified
+}
+
+// fromSDKError returns the status code and request ID of an error in the
+// SDK's "API error <status>: <body>" form. The request ID is read from the
+// body when it is a JSON document. It returns zero values for other errors.
+func fromSDKError(err error) (int, string) {
+	match := sdkErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, ""
+	}
+
+	code, _ := strconv.Atoi(match[1])
+	return code, requestIDFromBody([]byte(strings.TrimSpace(match[2])))
+}
+
+// IsKind reports whether err is an API error of the given kind.
+func IsKind(err error, kind Kind) bool {
+	classified := Classify(err)
+	return classified != nil && classified.Kind == kind
+}
+
+// IsNotFound reports whether err means the requested object does not exist,
+// in which case resources should remove themselves from state.
+func IsNotFound(err error) bool {
+	return IsKind(err, KindNotFound)
+}
+
+// Diagnostic builds an error diagnostic for a failed API call. detail should
+// describe what was attempted; the error, status code, request ID and a hint
+// for the error kind are appended to it.
+func Diagnostic(summary, detail string, err error) diag.Diagnostic {
+	classified := Classify(err)
+	if classified == nil {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+
+	message := detail + ": " + classified.Error()
+
+	if hint := classified.hint(); hint != "" {
+		message += "\n\n" + hint
+	}
+
+	if classified.StatusCode != 0 || classified.RequestID != "" {
+		message += "\n"
+		if classified.StatusCode != 0 {
+			message += fmt.Sprintf("\nHTTP status: %d (%s)", classified.StatusCode, classified.Kind)
+		}
+		if classified.RequestID != "" {
+			message += "\nRequest ID: " + classified.RequestID
+		}
+	}
+
+	return diag.NewErrorDiagnostic(summary, message)
+}
+
+func (e *Error) hint() string {
+	switch e.Kind {
+	case KindNotFound:
+		return "The object no longer exists in CacheFly. It may have been removed outside of Terraform."
+	case KindConflict:
+		return "The request conflicts with the current state of the object, for example a name that is already in use."
+	case KindRateLimited:
+		return "The CacheFly API rate limit was exceeded and retries were exhausted. " +
+			"Consider raising max_retries or lowering max_concurrent_requests in the provider configuration."
+	case KindValidation:
+		return "The CacheFly API rejected the request as invalid. Check the values in your configuration."
+	case KindUnauthorized:
+		return "The CacheFly API token was rejected. Check api_token or the CACHEFLY_API_TOKEN environment variable."
+	case KindForbidden:
+		return "The CacheFly API token does not have permission to perform this operation."
+	case KindUnavailable:
+		return "The CacheFly API is temporarily unavailable. Try again later."
+	case KindReadOnly:
+		return "The provider is configured with read_only = true (or CACHEFLY_READ_ONLY), so changes to CacheFly objects are blocked before any request is sent. " +
+			"Reads, imports and data sources keep working. Disable read_only to apply changes."
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,170 @@
+package apierrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+func TestClassify_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		kind       apierrors.Kind
+		statusCode int
+	}{
+		{
+			name:       "not found",
+			err:        errors.New(`API error 404: {"message":"Service not found"}`),
+			kind:       apierrors.KindNotFound,
+			statusCode: 404,
+		},
+		{
+			name:       "conflict",
+			err:        errors.New(`API error 409: {"message":"uniqueName already exists"}`),
+			kind:       apierrors.KindConflict,
+			statusCode: 409,
+		},
+		{
+			name:       "rate limited",
+			err:        errors.New("API error 429: Too Many Requests"),
+			kind:       apierrors.KindRateLimited,
+			statusCode: 429,
+		},
+		{
+			name:       "validation",
+			err:        errors.New(`API error 400: {"message":"invalid name"}`),
+			kind:       apierrors.KindValidation,
+			statusCode: 400,
+		},
+		{
+			name:       "unauthorized",
+			err:        errors.New("API error 401: Unauthorized"),
+			kind:       apierrors.KindUnauthorized,
+			statusCode: 401,
+		},
+		{
+			name:       "forbidden",
+			err:        errors.New("API error 403: Forbidden"),
+			kind:       apierrors.KindForbidden,
+			statusCode: 403,
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("failed to get service: %w", errors.New("API error 404: Not Found")),
+			kind:       apierrors.KindNotFound,
+			statusCode: 404,
+		},
+		{
+			name: "no status",
+			err:  errors.New("dial tcp: connection refused"),
+			kind: apierrors.KindUnknown,
+		},
+		{
+			name: "port in message",
+			err:  errors.New("dial tcp 1.2.3.4:443: i/o timeout"),
+			kind: apierrors.KindUnknown,
+		},
+		{
+			name: "bare code in message",
+			err:  errors.New("unexpected response 404 from proxy"),
+			kind: apierrors.KindUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classified := apierrors.Classify(tc.err)
+			if assert.NotNil(t, classified) {
+				assert.Equal(t, tc.kind, classified.Kind)
+				assert.Equal(t, tc.statusCode, classified.StatusCode)
+				assert.ErrorIs(t, classified, tc.err)
+			}
+		})
+	}
+}
+
+func TestClassify_ServiceOptionsValidationError(t *testing.T) {
+	err := api.ServiceOptionsValidationError{
+		Message: "Validation failed",
+		Errors: []api.OptionValidationError{
+			{Field: "ttfb_timeout", Message: "must be a number", Code: "invalid_type"},
+		},
+	}
+
+	classified := apierrors.Classify(err)
+	if assert.NotNil(t, classified) {
+		assert.Equal(t, apierrors.KindValidation, classified.Kind)
+		assert.Equal(t, 400, classified.StatusCode)
+	}
+}
+
+func TestClassify_RequestID(t *testing.T) {
+	err := errors.New(`API error 500: {"message":"internal error","requestId":"req-8f2c1a"}`)
+
+	classified := apierrors.Classify(err)
+	if assert.NotNil(t, classified) {
+		assert.Equal(t, "req-8f2c1a", classified.RequestID)
+		assert.Equal(t, 500, classified.StatusCode)
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	resp.Header.Set("X-Request-Id", "req-header")
+
+	classified := apierrors.FromResponse(resp, []byte(`{"message":"Service not found","requestId":"req-body"}`))
+
+	assert.Equal(t, apierrors.KindNotFound, classified.Kind)
+	assert.Equal(t, 404, classified.StatusCode)
+	assert.Equal(t, "req-header", classified.RequestID)
+	assert.True(t, apierrors.IsNotFound(fmt.Errorf("failed to read: %w", classified)))
+
+	resp.Header.Del("X-Request-Id")
+	assert.Equal(t, "req-body", apierrors.FromResponse(resp, []byte(`{"requestId":"req-body"}`)).RequestID)
+	assert.Empty(t, apierrors.FromResponse(resp, []byte(`request_id=req-text`)).RequestID)
+}
+
+func TestClassify_Nil(t *testing.T) {
+	assert.Nil(t, apierrors.Classify(nil))
+	assert.False(t, apierrors.IsNotFound(nil))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, apierrors.IsNotFound(errors.New("API error 404: Not Found")))
+	assert.True(t, apierrors.IsNotFound(errors.New("API error 410: Gone")))
+	assert.False(t, apierrors.IsNotFound(errors.New("API error 500: returned 404 bytes")))
+	assert.False(t, apierrors.IsNotFound(errors.New("API error 403: Forbidden")))
+}
+
+func TestDiagnostic(t *testing.T) {
+	err := errors.New(`API error 401: {"message":"invalid token","request_id":"abc123"}`)
+
+	d := apierrors.Diagnostic("Error Reading CacheFly Service", "Could not read service ID 123", err)
+
+	assert.Equal(t, "Error Reading CacheFly Service", d.Summary())
+	assert.Contains(t, d.Detail(), "Could not read service ID 123: API error 401")
+	assert.Contains(t, d.Detail(), "api_token")
+	assert.Contains(t, d.Detail(), "HTTP status: 401 (Unauthorized)")
+	assert.Contains(t, d.Detail(), "Request ID: abc123")
+}
+
+func TestClassify_ReadOnly(t *testing.T) {
+	err := fmt.Errorf("failed to update service: %w", apierrors.ErrReadOnly)
+	assert.True(t, apierrors.IsKind(err, apierrors.KindReadOnly))
+
+	// The SDK may flatten the transport error into its own message.
+	flattened := errors.New("request failed: Put \"https://api.cachefly.com/api/2.6/services/1\": " + apierrors.ErrReadOnly.Error())
+	assert.True(t, apierrors.IsKind(flattened, apierrors.KindReadOnly))
+
+	d := apierrors.Diagnostic("Error Updating CacheFly Service", "Could not update service ID 1", err)
+	assert.Contains(t, d.Detail(), "read_only")
+	assert.NotContains(t, d.Detail(), "HTTP status")
+}
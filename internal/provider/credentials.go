@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	defaultProfileName = "default"
+	defaultBaseURL     = "https://api.cachefly.com/api/2.6"
+)
+
+// credentialsProfile holds the settings of one profile in the shared
+// credentials file.
+type credentialsProfile struct {
+	APIToken string
+	BaseURL  string
+}
+
+// credentials are the resolved authentication settings for the provider.
+type credentials struct {
+	APIToken string
+	BaseURL  string
+
+	// Source describes where the token came from, for logging.
+	Source string
+}
+
+// defaultCredentialsFile returns ~/.cachefly/credentials.
+func defaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cachefly", "credentials"), nil
+}
+
+// expandHome replaces a leading "~" with the user's home directory.
+func expandHome(filename string) (string, error) {
+	if filename != "~" && !strings.HasPrefix(filename, "~/") {
+		return filename, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(filename, "~")), nil
+}
+
+// parseCredentialsFile reads an INI style credentials file. Each profile is
+// a [section] holding api_token and base_url keys; values may be quoted so
+// the same file can be written as TOML. Comments start with "#" or ";", either
+// on their own line or after whitespace following a header or value. Quoted
+// values may contain "#" and ";".
+//
+//	[default]
+//	api_token = "..."
+//
+//	[hybrid]
+//	api_token = "..."
+//	base_url  = "https://api.cachefly.com/api/2.6"
+func parseCredentialsFile(filename string) (map[string]credentialsProfile, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles := map[string]credentialsProfile{}
+	current := ""
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			header := stripInlineComment(line)
+			if !strings.HasSuffix(header, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid profile header %q", filename, lineNumber, line)
+			}
+			current = strings.TrimSpace(strings.Trim(header, "[]"))
+			current = strings.TrimPrefix(current, "profile ")
+			if _, ok := profiles[current]; !ok {
+				profiles[current] = credentialsProfile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", filename, lineNumber)
+		}
+		if current == "" {
+			return nil, fmt.Errorf("%s:%d: %q is not inside a [profile] section", filename, lineNumber, strings.TrimSpace(key))
+		}
+
+		key = strings.TrimSpace(key)
+		value = credentialsValue(value)
+
+		profile := profiles[current]
+		switch key {
+		case "api_token":
+			profile.APIToken = value
+		case "base_url":
+			profile.BaseURL = value
+		}
+		profiles[current] = profile
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// credentialsValue returns a value from the credentials file without its
+// quotes or trailing comment. A quoted value ends at its closing quote.
+func credentialsValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	return stripInlineComment(value)
+}
+
+// stripInlineComment removes a "#" or ";" comment that follows whitespace.
+func stripInlineComment(line string) string {
+	for i := 1; i < len(line); i++ {
+		if (line[i] == '#' || line[i] == ';') && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return line
+}
+
+// resolveCredentials determines the API token and base URL from, in order of
+// precedence:
+//
+//  1. the api_token and base_url provider attributes
+//  2. the token printed by api_token_command
+//  3. the profile named by the profile attribute or CACHEFLY_PROFILE
+//  4. the CACHEFLY_API_TOKEN and CACHEFLY_BASE_URL environment variables
+//  5. the default profile of the shared credentials file
+//
+// The shared credentials file is shared_credentials_file, then
+// CACHEFLY_SHARED_CREDENTIALS_FILE, then ~/.cachefly/credentials. A missing
+// file is only an error when the file or profile was named explicitly. A
+// profile's base_url is only used when the token also comes from that
+// profile, so a token from elsewhere is never sent to another endpoint.
+func resolveCredentials(ctx context.Context, config CacheFlyProviderModel, diags *diag.Diagnostics) credentials {
+	var creds credentials
+
+	if !config.APIToken.IsNull() && !config.APIToken.IsUnknown() {
+		creds.APIToken = config.APIToken.ValueString()
+		creds.Source = "api_token"
+	}
+
+	if creds.APIToken == "" && !config.APITokenCommand.IsNull() && !config.APITokenCommand.IsUnknown() {
+		creds.APIToken = tokenFromCommand(ctx, config, diags)
+		if diags.HasError() {
+			return creds
+		}
+		creds.Source = "api_token_command"
+	}
+	if !config.BaseURL.IsNull() && !config.BaseURL.IsUnknown() {
+		creds.BaseURL = config.BaseURL.ValueString()
+	}
+
+	profileName := getConfigValue(config.Profile, "CACHEFLY_PROFILE", "")
+	explicitProfile := profileName != ""
+	if !explicitProfile {
+		profileName = defaultProfileName
+	}
+
+	filename := getConfigValue(config.SharedCredentialsFile, "CACHEFLY_SHARED_CREDENTIALS_FILE", "")
+	explicitFile := filename != ""
+
+	var profile credentialsProfile
+	var profileFound bool
+
+	if creds.APIToken == "" {
+		profile, profileFound = loadProfile(filename, profileName, explicitFile || explicitProfile, explicitProfile, diags)
+		if diags.HasError() {
+			return creds
+		}
+	}
+
+	if explicitProfile {
+		applyProfile(&creds, profile, profileName)
+	}
+
+	if creds.APIToken == "" {
+		if token := os.Getenv("CACHEFLY_API_TOKEN"); token != "" {
+			creds.APIToken = token
+			creds.Source = "CACHEFLY_API_TOKEN"
+		}
+	}
+	if creds.BaseURL == "" {
+		creds.BaseURL = os.Getenv("CACHEFLY_BASE_URL")
+	}
+
+	if !explicitProfile && profileFound {
+		applyProfile(&creds, profile, profileName)
+	}
+
+	if creds.BaseURL == "" {
+		creds.BaseURL = defaultBaseURL
+	}
+
+	return creds
+}
+
+// loadProfile reads profileName from the shared credentials file. A missing
+// file or profile is silently ignored unless fileRequired or profileRequired
+// is set.
+func loadProfile(filename, profileName string, fileRequired, profileRequired bool, diags *diag.Diagnostics) (credentialsProfile, bool) {
+	attrPath := path.Root("shared_credentials_file")
+
+	var err error
+	if filename == "" {
+		filename, err = defaultCredentialsFile()
+	} else {
+		filename, err = expandHome(filename)
+	}
+	if err != nil {
+		if fileRequired {
+			diags.AddAttributeError(attrPath, "Invalid Shared Credentials File", "Could not determine the credentials file location: "+err.Error())
+		}
+		return credentialsProfile{}, false
+	}
+
+	profiles, err := parseCredentialsFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !fileRequired {
+			return credentialsProfile{}, false
+		}
+		diags.AddAttributeError(attrPath, "Invalid Shared Credentials File", "Could not read credentials file "+filename+": "+err.Error())
+		return credentialsProfile{}, false
+	}
+
+	profile, ok := profiles[profileName]
+	if !ok {
+		if profileRequired {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Invalid Credentials Profile",
+				fmt.Sprintf("Profile %q was not found in credentials file %s.", profileName, filename),
+			)
+		}
+		return credentialsProfile{}, false
+	}
+
+	return profile, true
+}
+
+// applyProfile takes the token from profile when no token was found yet,
+// along with the profile's base_url unless one was already set.
+func applyProfile(creds *credentials, profile credentialsProfile, profileName string) {
+	if creds.APIToken != "" || profile.APIToken == "" {
+		return
+	}
+
+	creds.APIToken = profile.APIToken
+	creds.Source = "profile " + profileName
+	if creds.BaseURL == "" {
+		creds.BaseURL = profile.BaseURL
+	}
+}
+
+// tokenFromCommand runs api_token_command with api_token_command_timeout.
+func tokenFromCommand(ctx context.Context, config CacheFlyProviderModel, diags *diag.Diagnostics) string {
+	var argv []string
+	diags.Append(config.APITokenCommand.ElementsAs(ctx, &argv, false)...)
+	if diags.HasError() {
+		return ""
+	}
+
+	timeout := defaultTokenCommandTimeout
+	if d, ok := parseDurationAttribute(config.APITokenCommandTimeout, path.Root("api_token_command_timeout"), diags); ok && d > 0 {
+		timeout = d
+	}
+	if diags.HasError() {
+		return ""
+	}
+
+	token, err := runTokenCommand(ctx, argv, timeout)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("api_token_command"),
+			"API Token Command Failed",
+			"Could not get the CacheFly API token from api_token_command: "+err.Error(),
+		)
+		return ""
+	}
+
+	return token
+}
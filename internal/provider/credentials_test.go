@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCredentialsFile = `
+# CacheFly credentials
+[default] ; used when no profile is named
+api_token = default-token
+base_url  = https://api.default.cachefly.com/api/2.6 # inline comment
+
+[hybrid]
+api_token = "hybrid-token" # work account
+base_url  = "https://api.hybrid.cachefly.com/api/2.6"
+
+; AWS style headers are accepted too
+[profile scim]
+api_token = 'scim-token'
+`
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestParseCredentialsFile(t *testing.T) {
+	profiles, err := parseCredentialsFile(writeCredentialsFile(t, testCredentialsFile))
+	assert.NoError(t, err)
+
+	assert.Equal(t, credentialsProfile{APIToken: "default-token", BaseURL: "https://api.default.cachefly.com/api/2.6"}, profiles["default"])
+	assert.Equal(t, credentialsProfile{APIToken: "hybrid-token", BaseURL: "https://api.hybrid.cachefly.com/api/2.6"}, profiles["hybrid"])
+	assert.Equal(t, credentialsProfile{APIToken: "scim-token"}, profiles["scim"])
+}
+
+func TestParseCredentialsFile_Comments(t *testing.T) {
+	profiles, err := parseCredentialsFile(writeCredentialsFile(t, `
+[default]   # comment after a header
+api_token = "to#ken;1" ; quoted values keep # and ;
+base_url  = https://api.cachefly.com/api/2.6#fragment
+`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, credentialsProfile{APIToken: "to#ken;1", BaseURL: "https://api.cachefly.com/api/2.6#fragment"}, profiles["default"])
+}
+
+func TestParseCredentialsFile_Invalid(t *testing.T) {
+	_, err := parseCredentialsFile(writeCredentialsFile(t, "api_token = orphan\n"))
+	assert.ErrorContains(t, err, "not inside a [profile] section")
+
+	_, err = parseCredentialsFile(writeCredentialsFile(t, "[default\napi_token = x\n"))
+	assert.ErrorContains(t, err, "invalid profile header")
+}
+
+func TestResolveCredentials(t *testing.T) {
+	filename := writeCredentialsFile(t, testCredentialsFile)
+
+	tests := []struct {
+		name           string
+		config         CacheFlyProviderModel
+		envVars        map[string]string
+		expectedToken  string
+		expectedURL    string
+		expectedSource string
+		errorSummary   string
+	}{
+		{
+			name: "attributes take precedence over everything",
+			config: CacheFlyProviderModel{
+				APIToken:              types.StringValue("attr-token"),
+				Profile:               types.StringValue("hybrid"),
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			envVars:        map[string]string{"CACHEFLY_API_TOKEN": "env-token"},
+			expectedToken:  "attr-token",
+			expectedURL:    defaultBaseURL,
+			expectedSource: "api_token",
+		},
+		{
+			name: "named profile takes precedence over environment",
+			config: CacheFlyProviderModel{
+				Profile:               types.StringValue("hybrid"),
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			envVars:        map[string]string{"CACHEFLY_API_TOKEN": "env-token"},
+			expectedToken:  "hybrid-token",
+			expectedURL:    "https://api.hybrid.cachefly.com/api/2.6",
+			expectedSource: "profile hybrid",
+		},
+		{
+			name:   "profile from environment",
+			config: CacheFlyProviderModel{},
+			envVars: map[string]string{
+				"CACHEFLY_PROFILE":                 "scim",
+				"CACHEFLY_SHARED_CREDENTIALS_FILE": filename,
+			},
+			expectedToken:  "scim-token",
+			expectedURL:    defaultBaseURL,
+			expectedSource: "profile scim",
+		},
+		{
+			name: "environment takes precedence over default profile",
+			config: CacheFlyProviderModel{
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			envVars:        map[string]string{"CACHEFLY_API_TOKEN": "env-token"},
+			expectedToken:  "env-token",
+			expectedURL:    defaultBaseURL,
+			expectedSource: "CACHEFLY_API_TOKEN",
+		},
+		{
+			name: "default profile used as a last resort",
+			config: CacheFlyProviderModel{
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			expectedToken:  "default-token",
+			expectedURL:    "https://api.default.cachefly.com/api/2.6",
+			expectedSource: "profile default",
+		},
+		{
+			name: "base_url from environment used with the default profile",
+			config: CacheFlyProviderModel{
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			envVars:        map[string]string{"CACHEFLY_BASE_URL": "https://api.env.cachefly.com/api/2.6"},
+			expectedToken:  "default-token",
+			expectedURL:    "https://api.env.cachefly.com/api/2.6",
+			expectedSource: "profile default",
+		},
+		{
+			name: "unknown profile",
+			config: CacheFlyProviderModel{
+				Profile:               types.StringValue("missing"),
+				SharedCredentialsFile: types.StringValue(filename),
+			},
+			errorSummary: "Invalid Credentials Profile",
+		},
+		{
+			name: "missing credentials file",
+			config: CacheFlyProviderModel{
+				SharedCredentialsFile: types.StringValue(filepath.Join(t.TempDir(), "missing")),
+			},
+			errorSummary: "Invalid Shared Credentials File",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"CACHEFLY_API_TOKEN", "CACHEFLY_BASE_URL", "CACHEFLY_PROFILE", "CACHEFLY_SHARED_CREDENTIALS_FILE"} {
+				t.Setenv(key, tt.envVars[key])
+			}
+			// Keep the real ~/.cachefly/credentials out of the test.
+			t.Setenv("HOME", t.TempDir())
+
+			var diags diag.Diagnostics
+			creds := resolveCredentials(context.Background(), tt.config, &diags)
+
+			if tt.errorSummary != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Equal(t, tt.errorSummary, diags.Errors()[0].Summary())
+				}
+				return
+			}
+
+			assert.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+			assert.Equal(t, tt.expectedToken, creds.APIToken)
+			assert.Equal(t, tt.expectedURL, creds.BaseURL)
+			assert.Equal(t, tt.expectedSource, creds.Source)
+		})
+	}
+}
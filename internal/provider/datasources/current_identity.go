@@ -0,0 +1,127 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/identity"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CurrentIdentityDataSource{}
+
+// NewCurrentIdentityDataSource creates a new data source instance.
+func NewCurrentIdentityDataSource() datasource.DataSource {
+	return &CurrentIdentityDataSource{}
+}
+
+// CurrentIdentityDataSource exposes the account and user behind the provider's API token.
+type CurrentIdentityDataSource struct {
+	identity *identity.Client
+}
+
+func (d *CurrentIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_identity"
+}
+
+func (d *CurrentIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "CacheFly Current Identity data source. Returns the account and user the provider's API token belongs to.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of this data source, the same as user_id.",
+				Computed:    true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the account.",
+				Computed:    true,
+			},
+			"account_name": schema.StringAttribute{
+				Description: "The company name of the account.",
+				Computed:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The unique identifier of the user.",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "The username of the user.",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the user.",
+				Computed:    true,
+			},
+			"full_name": schema.StringAttribute{
+				Description: "The full name of the user.",
+				Computed:    true,
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Set of permissions granted to the user.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *CurrentIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.identity = providerData.Identity
+}
+
+func (d *CurrentIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.CurrentIdentityDataSourceModel
+
+	id, err := d.identity.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(apierrors.Diagnostic(
+			"Error Reading CacheFly Current Identity",
+			"Could not look up the user and account of the API token",
+			err,
+		))
+		return
+	}
+
+	data.ID = types.StringValue(id.UserID)
+	data.AccountID = types.StringValue(id.AccountID)
+	data.AccountName = types.StringValue(id.AccountName)
+	data.UserID = types.StringValue(id.UserID)
+	data.Username = types.StringValue(id.Username)
+	data.Email = types.StringValue(id.Email)
+	data.FullName = types.StringValue(id.FullName)
+
+	permissionList := id.Permissions
+	if permissionList == nil {
+		permissionList = []string{}
+	}
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, permissionList)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Permissions = permissions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
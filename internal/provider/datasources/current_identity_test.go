@@ -0,0 +1,37 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider"
+)
+
+func TestAccCurrentIdentityDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCurrentIdentityDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.cachefly_current_identity.me", "account_id"),
+					resource.TestCheckResourceAttrSet("data.cachefly_current_identity.me", "user_id"),
+					resource.TestCheckResourceAttrSet("data.cachefly_current_identity.me", "username"),
+					resource.TestCheckResourceAttrSet("data.cachefly_current_identity.me", "permissions.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCurrentIdentityDataSourceConfig() string {
+	return `
+provider "cachefly" {
+  validate_credentials = true
+}
+
+data "cachefly_current_identity" "me" {}
+`
+}
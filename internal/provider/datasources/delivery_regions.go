@@ -12,6 +12,9 @@ import (
 
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -83,16 +86,16 @@ func (d *DeliveryRegionsDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *DeliveryRegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -122,10 +125,11 @@ func (d *DeliveryRegionsDataSource) Read(ctx context.Context, req datasource.Rea
 	for {
 		pageResp, err := d.client.DeliveryRegions.List(ctx, opts)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Delivery Regions",
-				"Could not read delivery regions: "+err.Error(),
-			)
+				"Could not read delivery regions",
+				err,
+			))
 			return
 		}
 
@@ -13,7 +13,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -163,16 +165,16 @@ func (d *LogTargetsDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *LogTargetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -207,10 +209,11 @@ func (d *LogTargetsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	for {
 		pageResp, err := d.client.LogTargets.List(ctx, opts)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Log Targets",
-				"Could not read log targets: "+err.Error(),
-			)
+				"Could not read log targets",
+				err,
+			))
 			return
 		}
 
@@ -12,7 +12,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -125,16 +127,16 @@ func (d *OriginDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *OriginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -151,10 +153,11 @@ func (d *OriginDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		data.ResponseType.ValueString(),
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Reading CacheFly Origin",
-			"Could not read origin ID "+data.ID.ValueString()+": "+err.Error(),
-		)
+			"Could not read origin ID "+data.ID.ValueString(),
+			err,
+		))
 		return
 	}
 
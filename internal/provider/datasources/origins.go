@@ -14,7 +14,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -141,16 +143,16 @@ func (d *OriginsDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *OriginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -180,10 +182,11 @@ func (d *OriginsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	for {
 		pageResp, err := d.client.Origins.List(ctx, opts)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Origins",
-				"Could not read origins: "+err.Error(),
-			)
+				"Could not read origins",
+				err,
+			))
 			return
 		}
 
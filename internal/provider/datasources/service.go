@@ -2,17 +2,24 @@ package datasources
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/lookup"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -40,12 +47,20 @@ func (d *ServiceDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			// Input attributes (one of these is required)
 			"id": schema.StringAttribute{
-				Description: "The unique identifier of the service. Either 'id' or 'unique_name' must be specified.",
+				Description: "The unique identifier of the service. Exactly one of 'id', 'unique_name' or 'domain' must be specified.",
 				Optional:    true,
+				Computed:    true,
 			},
 			"unique_name": schema.StringAttribute{
-				Description: "The unique name of the service. Either 'id' or 'unique_name' must be specified.",
+				Description: "The unique name of the service. Exactly one of 'id', 'unique_name' or 'domain' must be specified.",
 				Optional:    true,
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "A hostname served by the service, either one of its domains or its default <unique_name>.cachefly.net hostname. " +
+					"An active service with the domain is preferred over deactivated ones. " +
+					"Exactly one of 'id', 'unique_name' or 'domain' must be specified.",
+				Optional: true,
 			},
 
 			// Get method options (optional)
@@ -97,16 +112,16 @@ func (d *ServiceDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -118,22 +133,30 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// Validate that either ID or UniqueName is provided
+	// Validate that exactly one of ID, UniqueName or Domain is provided
 	hasID := !data.ID.IsNull() && !data.ID.IsUnknown()
 	hasUniqueName := !data.UniqueName.IsNull() && !data.UniqueName.IsUnknown()
+	hasDomain := !data.Domain.IsNull() && !data.Domain.IsUnknown()
+
+	var given int
+	for _, has := range []bool{hasID, hasUniqueName, hasDomain} {
+		if has {
+			given++
+		}
+	}
 
-	if !hasID && !hasUniqueName {
+	if given == 0 {
 		resp.Diagnostics.AddError(
 			"Missing Required Attribute",
-			"Either 'id' or 'unique_name' must be specified to look up a service.",
+			"One of 'id', 'unique_name' or 'domain' must be specified to look up a service.",
 		)
 		return
 	}
 
-	if hasID && hasUniqueName {
+	if given > 1 {
 		resp.Diagnostics.AddError(
 			"Conflicting Attributes",
-			"Only one of 'id' or 'unique_name' should be specified, not both.",
+			"Only one of 'id', 'unique_name' or 'domain' should be specified.",
 		)
 		return
 	}
@@ -166,10 +189,17 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		}
 
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Service",
-				"Could not read service by ID "+serviceID+": "+err.Error(),
-			)
+				"Could not read service by ID "+serviceID,
+				err,
+			))
+			return
+		}
+
+	} else if hasDomain {
+		service = d.serviceByDomain(ctx, data.Domain.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
@@ -177,52 +207,22 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		// Look up by unique name - we need to list services and filter
 		uniqueName := data.UniqueName.ValueString()
 
-		// List all services and find the one with matching unique name
-		listOptions := api.ListOptions{
-			Limit:  100, // Fetch in pages
-			Offset: 0,
-		}
-
-		found := false
-		for {
-			listResp, err := d.client.Services.List(ctx, listOptions)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error Listing CacheFly Services",
-					"Could not list services to find by unique name: "+err.Error(),
-				)
-				return
-			}
-
-			// Find service with matching unique name in this page
-			for i := range listResp.Services {
-				if listResp.Services[i].UniqueName == uniqueName {
-					service = &listResp.Services[i]
-					found = true
-					break
-				}
-			}
-
-			if found {
-				break
-			}
-
-			fetched := len(listResp.Services)
-
-			listOptions.Offset += fetched
-
-			if fetched < listOptions.Limit || listResp.Meta.Count > 0 && listOptions.Offset == listResp.Meta.Count {
-				break
-			}
-		}
-
-		if !found {
+		service, err = lookup.ServiceByUniqueName(ctx, d.client, uniqueName)
+		if errors.Is(err, lookup.ErrNotFound) {
 			resp.Diagnostics.AddError(
 				"Service Not Found",
 				fmt.Sprintf("Could not find service with unique name: %s", uniqueName),
 			)
 			return
 		}
+		if err != nil {
+			resp.Diagnostics.Append(apierrors.Diagnostic(
+				"Error Listing CacheFly Services",
+				"Could not list services to find by unique name",
+				err,
+			))
+			return
+		}
 	}
 
 	// Map the service data to our model
@@ -238,10 +238,11 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Load and map service options from API
 	allOptions, err := d.client.ServiceOptions.GetOptions(ctx, service.ID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Reading Service Options",
-			"Could not read service options: "+err.Error(),
-		)
+			"Could not read service options",
+			err,
+		))
 		return
 	}
 
@@ -257,6 +258,42 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// serviceByDomain returns the one service that serves hostname.
+func (d *ServiceDataSource) serviceByDomain(ctx context.Context, hostname string, diags *diag.Diagnostics) *api.Service {
+	services, err := lookup.ServicesByHostname(ctx, d.client, hostname)
+	if err != nil {
+		diags.Append(apierrors.Diagnostic(
+			"Error Listing CacheFly Services",
+			"Could not search service domains for "+hostname,
+			err,
+		))
+		return nil
+	}
+
+	switch len(services) {
+	case 0:
+		diags.AddAttributeError(
+			path.Root("domain"),
+			"Service Not Found",
+			fmt.Sprintf("No service serves %s. It is not a domain of any service, nor the default <unique_name>%s hostname of one.", hostname, lookup.DefaultHostnameSuffix),
+		)
+		return nil
+	case 1:
+		return &services[0]
+	}
+
+	matches := make([]string, len(services))
+	for i, service := range services {
+		matches[i] = fmt.Sprintf("%s (%s)", service.UniqueName, service.ID)
+	}
+	diags.AddAttributeError(
+		path.Root("domain"),
+		"Multiple Services Found",
+		fmt.Sprintf("%d services serve %s: %s. Look the service up by id or unique_name instead.", len(services), hostname, strings.Join(matches, ", ")),
+	)
+	return nil
+}
+
 // setOptionsFromAPI converts API ServiceOptions directly to the DataSource model's Options field
 func setOptionsFromAPI(data *models.ServiceDataSourceModel, options api.ServiceOptions) error {
 	if len(options) > 0 {
@@ -13,7 +13,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -91,16 +93,16 @@ func (d *ServiceDomainDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *ServiceDomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -118,10 +120,11 @@ func (d *ServiceDomainDataSource) Read(ctx context.Context, req datasource.ReadR
 		data.ResponseType.ValueString(),
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Reading CacheFly Service Domain",
-			"Could not read service domain ID "+data.ID.ValueString()+": "+err.Error(),
-		)
+			"Could not read service domain ID "+data.ID.ValueString(),
+			err,
+		))
 		return
 	}
 
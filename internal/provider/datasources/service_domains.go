@@ -13,7 +13,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -111,16 +113,16 @@ func (d *ServiceDomainsDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
 }
 
 func (d *ServiceDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -150,10 +152,11 @@ func (d *ServiceDomainsDataSource) Read(ctx context.Context, req datasource.Read
 	for {
 		pageResp, err := d.client.ServiceDomains.List(ctx, data.ServiceID.ValueString(), opts)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Service Domains",
-				"Could not read service domains for service "+data.ServiceID.ValueString()+": "+err.Error(),
-			)
+				"Could not read service domains for service "+data.ServiceID.ValueString(),
+				err,
+			))
 			return
 		}
 
@@ -2,6 +2,7 @@ package datasources_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -39,6 +40,33 @@ func TestAccServiceDataSource_ByIDAndUniqueName(t *testing.T) {
 	})
 }
 
+func TestAccServiceDataSource_ByDomain(t *testing.T) {
+	rName := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	domain := rName + ".example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceDataSourceConfigByDomain(rName, domain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// By a domain of the service
+					resource.TestCheckResourceAttrPair("data.cachefly_service.by_domain", "id", "cachefly_service."+rName, "id"),
+					resource.TestCheckResourceAttrPair("data.cachefly_service.by_domain", "unique_name", "cachefly_service."+rName, "unique_name"),
+
+					// By the default hostname
+					resource.TestCheckResourceAttrPair("data.cachefly_service.by_default_hostname", "id", "cachefly_service."+rName, "id"),
+				),
+			},
+			{
+				Config:      testAccServiceDataSourceConfigByDomain(rName, domain) + testAccServiceDataSourceConfigUnknownDomain(rName),
+				ExpectError: regexp.MustCompile(`Service Not Found`),
+			},
+		},
+	})
+}
+
 func testAccServiceDataSourceConfig(name string) string {
 	return fmt.Sprintf(`
 provider "cachefly" {}
@@ -58,3 +86,37 @@ data "cachefly_service" "by_unique" {
 }
 `, name)
 }
+
+func testAccServiceDataSourceConfigByDomain(name, domain string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name        = %[1]q
+  unique_name = "%[1]s-unique"
+  description = "%[1]s description"
+}
+
+resource "cachefly_service_domain" %[1]q {
+  service_id      = cachefly_service.%[1]s.id
+  name            = %[2]q
+  validation_mode = "HTTP"
+}
+
+data "cachefly_service" "by_domain" {
+  domain = upper(cachefly_service_domain.%[1]s.name)
+}
+
+data "cachefly_service" "by_default_hostname" {
+  domain = "${cachefly_service.%[1]s.unique_name}.cachefly.net"
+}
+`, name, domain)
+}
+
+func testAccServiceDataSourceConfigUnknownDomain(name string) string {
+	return fmt.Sprintf(`
+data "cachefly_service" "unknown" {
+  domain = "missing-%[1]s.example.com"
+}
+`, name)
+}
@@ -0,0 +1,379 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/lookup"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
+)
+
+// servicesPageSize is the number of services fetched per list request when
+// the list is read directly from the API.
+const servicesPageSize = 100
+
+// serviceWithSettings is a service list entry including the settings that
+// the SDK service type does not have.
+type serviceWithSettings struct {
+	api.Service
+	TLSProfile     string
+	DeliveryRegion string
+}
+
+// UnmarshalJSON decodes a service list entry. The TLS profile and delivery
+// region are references, which the API returns as an ID or, with
+// response_type = "deep", as the referenced object.
+func (s *serviceWithSettings) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.Service); err != nil {
+		return err
+	}
+
+	var raw struct {
+		TLSProfile     json.RawMessage `json:"tlsProfile"`
+		DeliveryRegion json.RawMessage `json:"deliveryRegion"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if s.TLSProfile, err = apiclient.ReferenceID(raw.TLSProfile); err != nil {
+		return fmt.Errorf("tlsProfile: %w", err)
+	}
+	if s.DeliveryRegion, err = apiclient.ReferenceID(raw.DeliveryRegion); err != nil {
+		return fmt.Errorf("deliveryRegion: %w", err)
+	}
+	return nil
+}
+
+// serviceSettingsListResponse is the services list response decoded with
+// serviceWithSettings entries.
+type serviceSettingsListResponse struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Data []serviceWithSettings `json:"data"`
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &ServicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServicesDataSource{}
+)
+
+func NewServicesDataSource() datasource.DataSource {
+	return &ServicesDataSource{}
+}
+
+// ServicesDataSource defines the data source implementation.
+type ServicesDataSource struct {
+	client *cachefly.Client
+	api    *apiclient.Client
+}
+
+func (d *ServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_services"
+}
+
+func (d *ServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "CacheFly Services data source. Lists all services of the account, fetching every page, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				Description: "Only list services with this status, for example 'ACTIVE' or 'DEACTIVATED'.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list services whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"unique_name_regex": schema.StringAttribute{
+				Description: "Only list services whose unique name matches this regular expression.",
+				Optional:    true,
+			},
+			"delivery_region": schema.StringAttribute{
+				Description: "Only list services with this delivery region.",
+				Optional:    true,
+			},
+			"tls_profile": schema.StringAttribute{
+				Description: "Only list services with this TLS profile.",
+				Optional:    true,
+			},
+			"response_type": schema.StringAttribute{
+				Description: "Optional response type parameter for the API call.",
+				Optional:    true,
+			},
+			"include_features": schema.BoolAttribute{
+				Description: "Whether to include features in the response.",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: "Maximum number of matching services to return. All matching services are returned if not set.",
+				Optional:    true,
+			},
+			"offset": schema.Int64Attribute{
+				Description: "Number of matching services to skip.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "IDs of the matching services.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"services": schema.ListNestedAttribute{
+				Description: "List of matching services.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the service.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The display name of the service.",
+							Computed:    true,
+						},
+						"unique_name": schema.StringAttribute{
+							Description: "The unique name of the service.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the service.",
+							Computed:    true,
+						},
+						"auto_ssl": schema.BoolAttribute{
+							Description: "Whether SSL certificates are provisioned automatically.",
+							Computed:    true,
+						},
+						"configuration_mode": schema.StringAttribute{
+							Description: "The configuration mode of the service.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the service.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "When the service was created.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "When the service was last updated.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"meta": schema.SingleNestedAttribute{
+				Description: "Metadata about the returned list.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"limit": schema.Int64Attribute{
+						Description: "The limit that was applied, or 0 if none was.",
+						Computed:    true,
+					},
+					"offset": schema.Int64Attribute{
+						Description: "The number of matching services that were skipped.",
+						Computed:    true,
+					},
+					"count": schema.Int64Attribute{
+						Description: "The total number of matching services.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.api = providerData.API
+}
+
+func (d *ServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.ServicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileFilter(data.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	uniqueNameRegex := compileFilter(data.UniqueNameRegex, path.Root("unique_name_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := api.ListOptions{
+		Status:          data.Status.ValueString(),
+		ResponseType:    data.ResponseType.ValueString(),
+		IncludeFeatures: data.IncludeFeatures.ValueBool(),
+	}
+
+	var matched []api.Service
+	keep := func(service *api.Service) bool {
+		if nameRegex != nil && !nameRegex.MatchString(service.Name) {
+			return false
+		}
+		if uniqueNameRegex != nil && !uniqueNameRegex.MatchString(service.UniqueName) {
+			return false
+		}
+		return true
+	}
+
+	var err error
+	if data.DeliveryRegion.IsNull() && data.TLSProfile.IsNull() {
+		err = lookup.EachService(ctx, d.client, opts, func(service *api.Service) bool {
+			if keep(service) {
+				matched = append(matched, *service)
+			}
+			return true
+		})
+	} else {
+		err = d.eachServiceWithSettings(ctx, opts, func(service *serviceWithSettings) {
+			if !data.DeliveryRegion.IsNull() && service.DeliveryRegion != data.DeliveryRegion.ValueString() {
+				return
+			}
+			if !data.TLSProfile.IsNull() && service.TLSProfile != data.TLSProfile.ValueString() {
+				return
+			}
+			if keep(&service.Service) {
+				matched = append(matched, service.Service)
+			}
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apierrors.Diagnostic(
+			"Error Reading CacheFly Services",
+			"Could not list services",
+			err,
+		))
+		return
+	}
+
+	data.Meta = models.ServiceListMeta{
+		Limit:  types.Int64Value(data.Limit.ValueInt64()),
+		Offset: types.Int64Value(data.Offset.ValueInt64()),
+		Count:  types.Int64Value(int64(len(matched))),
+	}
+	if offset := data.Offset.ValueInt64(); offset > 0 {
+		if offset > int64(len(matched)) {
+			offset = int64(len(matched))
+		}
+		matched = matched[offset:]
+	}
+	if limit := data.Limit.ValueInt64(); limit > 0 && limit < int64(len(matched)) {
+		matched = matched[:limit]
+	}
+
+	ids := make([]string, 0, len(matched))
+	data.Services = make([]models.ServiceListItem, 0, len(matched))
+	for _, service := range matched {
+		ids = append(ids, service.ID)
+		data.Services = append(data.Services, models.ServiceListItem{
+			ID:                types.StringValue(service.ID),
+			Name:              types.StringValue(service.Name),
+			UniqueName:        types.StringValue(service.UniqueName),
+			Description:       types.StringValue(service.Description),
+			AutoSSL:           types.BoolValue(service.AutoSSL),
+			ConfigurationMode: types.StringValue(service.ConfigurationMode),
+			Status:            types.StringValue(service.Status),
+			CreatedAt:         types.StringValue(service.CreatedAt),
+			UpdatedAt:         types.StringValue(service.UpdatedAt),
+		})
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// eachServiceWithSettings calls fn for every service matching the status,
+// response type and features of opts; its limit and offset are ignored. The
+// SDK list response has no TLS profile or delivery region, so the list is
+// read directly from the API, one request per page.
+func (d *ServicesDataSource) eachServiceWithSettings(ctx context.Context, opts api.ListOptions, fn func(*serviceWithSettings)) error {
+	if d.api == nil {
+		return fmt.Errorf("no API client is configured")
+	}
+
+	for offset := 0; ; {
+		query := url.Values{}
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(servicesPageSize))
+		if opts.Status != "" {
+			query.Set("status", opts.Status)
+		}
+		if opts.ResponseType != "" {
+			query.Set("responseType", opts.ResponseType)
+		}
+		if opts.IncludeFeatures {
+			query.Set("includeFeatures", "true")
+		}
+
+		var listResp serviceSettingsListResponse
+		if err := d.api.Get(ctx, "/services?"+query.Encode(), &listResp); err != nil {
+			return err
+		}
+		for i := range listResp.Data {
+			fn(&listResp.Data[i])
+		}
+
+		offset += len(listResp.Data)
+		// A response without a count is paged by its length alone.
+		if len(listResp.Data) < servicesPageSize || (listResp.Meta.Count > 0 && offset >= listResp.Meta.Count) {
+			return nil
+		}
+	}
+}
+
+// compileFilter compiles a regular expression filter, or returns nil if it
+// is not set.
+func compileFilter(value types.String, attributePath path.Path, diags *diag.Diagnostics) *regexp.Regexp {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	re, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid Regular Expression", err.Error())
+		return nil
+	}
+	return re
+}
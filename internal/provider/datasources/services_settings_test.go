@@ -0,0 +1,60 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+func TestEachServiceWithSettings(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		// A full first page and a short second one, without a count.
+		count := servicesPageSize
+		if offset > 0 {
+			count = 1
+		}
+		data := make([]map[string]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			data = append(data, map[string]interface{}{
+				"_id":            "svc-" + strconv.Itoa(offset+i),
+				"uniqueName":     "service-" + strconv.Itoa(offset+i),
+				"tlsProfile":     map[string]interface{}{"_id": "tls-1", "name": "Modern"},
+				"deliveryRegion": "region-1",
+			})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]interface{}{}, "data": data}))
+	}))
+	defer server.Close()
+
+	d := &ServicesDataSource{api: apiclient.New(server.Client(), server.URL, "token")}
+	opts := api.ListOptions{Status: "ACTIVE", ResponseType: "deep", IncludeFeatures: true}
+
+	var services []serviceWithSettings
+	err := d.eachServiceWithSettings(context.Background(), opts, func(service *serviceWithSettings) {
+		services = append(services, *service)
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, services, servicesPageSize+1)
+	assert.Equal(t, "svc-0", services[0].ID)
+	assert.Equal(t, "service-0", services[0].UniqueName)
+	assert.Equal(t, "tls-1", services[0].TLSProfile)
+	assert.Equal(t, "region-1", services[0].DeliveryRegion)
+
+	if assert.Len(t, queries, 2) {
+		assert.Equal(t, "includeFeatures=true&limit=100&offset=0&responseType=deep&status=ACTIVE", queries[0])
+	}
+}
@@ -0,0 +1,52 @@
+package datasources_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider"
+)
+
+func TestAccServicesDataSource_Filter(t *testing.T) {
+	rName := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicesDataSourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Only the service created here matches its unique name
+					resource.TestCheckResourceAttr("data.cachefly_services.filtered", "services.#", "1"),
+					resource.TestCheckResourceAttrPair("data.cachefly_services.filtered", "ids.0", "cachefly_service."+rName, "id"),
+					resource.TestCheckResourceAttr("data.cachefly_services.filtered", "services.0.unique_name", rName+"-unique"),
+					resource.TestCheckResourceAttr("data.cachefly_services.filtered", "services.0.status", "ACTIVE"),
+					resource.TestCheckResourceAttr("data.cachefly_services.filtered", "meta.count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServicesDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name        = %[1]q
+  unique_name = "%[1]s-unique"
+  description = "%[1]s service for list testing"
+}
+
+data "cachefly_services" "filtered" {
+  status            = "ACTIVE"
+  unique_name_regex = "^%[1]s-unique$"
+
+  depends_on = [cachefly_service.%[1]s]
+}
+`, name)
+}
@@ -0,0 +1,97 @@
+// Package identity looks up the CacheFly account and user behind the
+// provider's API token.
+package identity
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+// Identity describes who the API token belongs to.
+type Identity struct {
+	AccountID   string
+	AccountName string
+	UserID      string
+	Username    string
+	Email       string
+	FullName    string
+	Permissions []string
+}
+
+type accountResponse struct {
+	ID          string `json:"_id"`
+	AltID       string `json:"id"`
+	CompanyName string `json:"companyName"`
+}
+
+type userResponse struct {
+	ID          string   `json:"_id"`
+	AltID       string   `json:"id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	FullName    string   `json:"fullName"`
+	Permissions []string `json:"permissions"`
+}
+
+// Client fetches the identity once and remembers it for the lifetime of the
+// provider.
+type Client struct {
+	api *apiclient.Client
+
+	mu       sync.Mutex
+	identity *Identity
+}
+
+// NewClient returns a Client that uses httpClient, so identity lookups share
+// the retry, limit and read-only handling of the SDK client.
+func NewClient(httpClient *http.Client, baseURL, token string) *Client {
+	return &Client{
+		api: apiclient.New(httpClient, baseURL, token),
+	}
+}
+
+// Get returns the identity behind the token. The current user is requested
+// first, so an invalid token or missing permission is reported by the first
+// call.
+func (c *Client) Get(ctx context.Context) (*Identity, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.identity != nil {
+		return c.identity, nil
+	}
+
+	var user userResponse
+	if err := c.api.Get(ctx, "/users/me", &user); err != nil {
+		return nil, err
+	}
+
+	var account accountResponse
+	if err := c.api.Get(ctx, "/accounts/me", &account); err != nil {
+		return nil, err
+	}
+
+	c.identity = &Identity{
+		AccountID:   firstNonEmpty(account.ID, account.AltID),
+		AccountName: account.CompanyName,
+		UserID:      firstNonEmpty(user.ID, user.AltID),
+		Username:    user.Username,
+		Email:       user.Email,
+		FullName:    user.FullName,
+		Permissions: user.Permissions,
+	}
+
+	return c.identity, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
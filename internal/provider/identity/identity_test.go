@@ -0,0 +1,65 @@
+package identity_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/identity"
+)
+
+func TestClient_Get(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/api/2.6/users/me":
+			_, _ = w.Write([]byte(`{"_id":"u-1","username":"ops","email":"ops@example.com","fullName":"Ops Team","permissions":["SERVICE_MANAGEMENT","USER_MANAGEMENT"]}`))
+		case "/api/2.6/accounts/me":
+			_, _ = w.Write([]byte(`{"_id":"a-1","companyName":"Example Inc"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := identity.NewClient(server.Client(), server.URL+"/api/2.6/", "test-token")
+
+	id, err := client.Get(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, &identity.Identity{
+			AccountID:   "a-1",
+			AccountName: "Example Inc",
+			UserID:      "u-1",
+			Username:    "ops",
+			Email:       "ops@example.com",
+			FullName:    "Ops Team",
+			Permissions: []string{"SERVICE_MANAGEMENT", "USER_MANAGEMENT"},
+		}, id)
+	}
+
+	// The identity is cached after the first lookup.
+	_, err = client.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestClient_GetUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Invalid token"}`))
+	}))
+	defer server.Close()
+
+	client := identity.NewClient(server.Client(), server.URL, "bad-token")
+
+	_, err := client.Get(context.Background())
+	assert.True(t, apierrors.IsKind(err, apierrors.KindUnauthorized), "got %v", err)
+}
@@ -0,0 +1,171 @@
+// Package lookup finds CacheFly objects by attributes other than their ID,
+// paging through list endpoints as needed.
+package lookup
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+)
+
+// ErrNotFound is returned when no object matches.
+var ErrNotFound = errors.New("not found")
+
+// pageSize is the number of objects fetched per list request.
+const pageSize = 100
+
+// EachService calls fn for every service matching opts, fetching one page at
+// a time, until fn returns false. opts.Offset and opts.Limit are managed by
+// EachService.
+func EachService(ctx context.Context, client *cachefly.Client, opts api.ListOptions, fn func(*api.Service) bool) error {
+	opts.Offset = 0
+	opts.Limit = pageSize
+
+	for {
+		listResp, err := client.Services.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for i := range listResp.Services {
+			if !fn(&listResp.Services[i]) {
+				return nil
+			}
+		}
+
+		fetched := len(listResp.Services)
+		opts.Offset += fetched
+
+		if fetched == 0 || fetched < opts.Limit || listResp.Meta.Count > 0 && opts.Offset >= listResp.Meta.Count {
+			return nil
+		}
+	}
+}
+
+// ServiceByUniqueName returns the service with the given unique name,
+// whatever its status. It returns ErrNotFound if there is none.
+func ServiceByUniqueName(ctx context.Context, client *cachefly.Client, uniqueName string) (*api.Service, error) {
+	var service *api.Service
+
+	err := EachService(ctx, client, api.ListOptions{}, func(candidate *api.Service) bool {
+		if candidate.UniqueName == uniqueName {
+			found := *candidate
+			service = &found
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if service == nil {
+		return nil, ErrNotFound
+	}
+	return service, nil
+}
+
+// DefaultHostnameSuffix completes the default hostname of a service,
+// <unique_name>.cachefly.net.
+const DefaultHostnameSuffix = ".cachefly.net"
+
+// ServicesByHostname returns the services that serve hostname, either as one
+// of their domains or as their default hostname. Hostnames are compared
+// without regard to case or a trailing dot.
+//
+// A default hostname is resolved from the unique name, without listing any
+// domains. Otherwise the domains of active services are searched first, one
+// request per service, stopping at the first match, since only one active
+// service can serve a hostname. Deactivated services are searched only if no
+// active service has the domain, and every match among them is returned.
+func ServicesByHostname(ctx context.Context, client *cachefly.Client, hostname string) ([]api.Service, error) {
+	hostname = normalizeHostname(hostname)
+
+	if uniqueName, ok := strings.CutSuffix(hostname, DefaultHostnameSuffix); ok && uniqueName != "" && !strings.Contains(uniqueName, ".") {
+		return servicesByUniqueName(ctx, client, uniqueName)
+	}
+
+	services, err := servicesWithDomain(ctx, client, "ACTIVE", hostname, true)
+	if err != nil || len(services) > 0 {
+		return services, err
+	}
+	return servicesWithDomain(ctx, client, "DEACTIVATED", hostname, false)
+}
+
+// servicesByUniqueName returns the service whose unique name matches
+// uniqueName without regard to case, or none.
+func servicesByUniqueName(ctx context.Context, client *cachefly.Client, uniqueName string) ([]api.Service, error) {
+	var services []api.Service
+
+	err := EachService(ctx, client, api.ListOptions{}, func(service *api.Service) bool {
+		if strings.EqualFold(service.UniqueName, uniqueName) {
+			services = append(services, *service)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// servicesWithDomain returns the services with the given status that have a
+// domain named hostname. If first is true, it stops at the first one.
+func servicesWithDomain(ctx context.Context, client *cachefly.Client, status, hostname string, first bool) ([]api.Service, error) {
+	var services []api.Service
+	var listErr error
+
+	err := EachService(ctx, client, api.ListOptions{Status: status}, func(service *api.Service) bool {
+		found, err := hasDomain(ctx, client, service.ID, hostname)
+		if err != nil {
+			listErr = err
+			return false
+		}
+		if found {
+			services = append(services, *service)
+			return !first
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	return services, nil
+}
+
+// hasDomain reports whether the service has a domain named hostname.
+func hasDomain(ctx context.Context, client *cachefly.Client, serviceID, hostname string) (bool, error) {
+	opts := api.ListServiceDomainsOptions{Search: hostname, Limit: pageSize}
+
+	for {
+		listResp, err := client.ServiceDomains.List(ctx, serviceID, opts)
+		if err != nil {
+			return false, err
+		}
+
+		for _, domain := range listResp.Domains {
+			if normalizeHostname(domain.Name) == hostname {
+				return true, nil
+			}
+		}
+
+		fetched := len(listResp.Domains)
+		opts.Offset += fetched
+
+		if fetched == 0 || fetched < opts.Limit || listResp.Meta.Count > 0 && opts.Offset >= listResp.Meta.Count {
+			return false, nil
+		}
+	}
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CurrentIdentityDataSourceModel represents the Terraform model for the
+// cachefly_current_identity data source
+type CurrentIdentityDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	AccountID   types.String `tfsdk:"account_id"`
+	AccountName types.String `tfsdk:"account_name"`
+	UserID      types.String `tfsdk:"user_id"`
+	Username    types.String `tfsdk:"username"`
+	Email       types.String `tfsdk:"email"`
+	FullName    types.String `tfsdk:"full_name"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
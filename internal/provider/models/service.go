@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
 )
 
 type ServiceResourceModel struct {
@@ -22,6 +24,21 @@ type ServiceResourceModel struct {
 	TLSProfile        types.String  `tfsdk:"tls_profile"`
 	DeliveryRegion    types.String  `tfsdk:"delivery_region"`
 	Options           types.Dynamic `tfsdk:"options"`
+	TypedOptions      types.Object  `tfsdk:"typed_options"`
+
+	OptionsRemovalBehavior types.String  `tfsdk:"options_removal_behavior"`
+	ExclusiveOptions       types.Bool    `tfsdk:"exclusive_options"`
+	UnmanagedOptions       types.Dynamic `tfsdk:"unmanaged_options"`
+
+	SourceServiceID types.String `tfsdk:"source_service_id"`
+	SourceBaseline  types.Object `tfsdk:"source_baseline"`
+
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	DeletionPolicy     types.String `tfsdk:"deletion_policy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+
+	WaitForDeployment types.Bool            `tfsdk:"wait_for_deployment"`
+	Timeouts          *ServiceTimeoutsModel `tfsdk:"timeouts"`
 
 	//read-only fields
 	Status    types.String `tfsdk:"status"`
@@ -29,10 +46,39 @@ type ServiceResourceModel struct {
 	UpdatedAt types.String `tfsdk:"updated_at"`
 }
 
+// ServiceTimeoutsModel holds the timeouts block of cachefly_service.
+type ServiceTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+}
+
+// ServiceSourceBaselineModel holds what a service copied from its source
+// service when it was created.
+type ServiceSourceBaselineModel struct {
+	Options        types.Dynamic `tfsdk:"options"`
+	TLSProfile     types.String  `tfsdk:"tls_profile"`
+	DeliveryRegion types.String  `tfsdk:"delivery_region"`
+}
+
+// ServiceSourceBaselineAttrTypes are the attribute types of the
+// source_baseline object.
+var ServiceSourceBaselineAttrTypes = map[string]attr.Type{
+	"options":         types.DynamicType,
+	"tls_profile":     types.StringType,
+	"delivery_region": types.StringType,
+}
+
+// TypedOptionsAttrTypes returns the attribute types of the typed_options
+// object, which has one attribute per option of the built-in catalog.
+func TypedOptionsAttrTypes() map[string]attr.Type {
+	return serviceoptions.Builtin().AttributeTypes()
+}
+
 type ServiceDataSourceModel struct {
 	// Lookup fields (one of these should be provided)
 	ID         types.String `tfsdk:"id"`
 	UniqueName types.String `tfsdk:"unique_name"`
+	Domain     types.String `tfsdk:"domain"`
 
 	// Options for Get() method
 	ResponseType    types.String `tfsdk:"response_type"`
@@ -48,12 +94,19 @@ type ServiceDataSourceModel struct {
 }
 
 type ServicesDataSourceModel struct {
-	Status          types.String `tfsdk:"status"`
+	// Filters
+	Status          types.String `tfsdk:"status"` // maps to ListOptions.Status
+	NameRegex       types.String `tfsdk:"name_regex"`
+	UniqueNameRegex types.String `tfsdk:"unique_name_regex"`
+	DeliveryRegion  types.String `tfsdk:"delivery_region"`
+	TLSProfile      types.String `tfsdk:"tls_profile"`
+
 	ResponseType    types.String `tfsdk:"response_type"`
 	IncludeFeatures types.Bool   `tfsdk:"include_features"`
 	Limit           types.Int64  `tfsdk:"limit"`
 	Offset          types.Int64  `tfsdk:"offset"` // maps to ListOptions.Offset
 
+	IDs      types.List        `tfsdk:"ids"`
 	Services []ServiceListItem `tfsdk:"services"` // maps to ListServicesResponse.Services (data field)
 	Meta     ServiceListMeta   `tfsdk:"meta"`
 }
@@ -62,6 +115,7 @@ type ServiceListItem struct {
 	ID                types.String `tfsdk:"id"`
 	Name              types.String `tfsdk:"name"`
 	UniqueName        types.String `tfsdk:"unique_name"`
+	Description       types.String `tfsdk:"description"`
 	AutoSSL           types.Bool   `tfsdk:"auto_ssl"`
 	ConfigurationMode types.String `tfsdk:"configuration_mode"`
 	Status            types.String `tfsdk:"status"`
@@ -75,15 +129,44 @@ type ServiceListMeta struct {
 	Count  types.Int64 `tfsdk:"count"`  // maps to MetaInfo.Count
 }
 
-// ToAPIServiceOptions converts Terraform model to API ServiceOptions
+// ToAPIServiceOptions converts Terraform model to API ServiceOptions. The
+// options in typed_options are merged with the ones in options; an option
+// set in both is an error.
 func (m *ServiceResourceModel) ToAPIServiceOptions() (api.ServiceOptions, error) {
-	if m.Options.IsNull() || m.Options.IsUnknown() {
+	options, err := DynamicToAPIServiceOptions(m.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range serviceoptions.Builtin().FromObject(m.TypedOptions) {
+		if _, ok := options[key]; ok {
+			return nil, fmt.Errorf("option %s is set in both options and typed_options", key)
+		}
+		options[key] = value
+	}
+
+	return options, nil
+}
+
+// TypedOptionNames returns the API names of the options set in
+// typed_options.
+func (m *ServiceResourceModel) TypedOptionNames() map[string]bool {
+	names := make(map[string]bool)
+	for key := range serviceoptions.Builtin().FromObject(m.TypedOptions) {
+		names[key] = true
+	}
+	return names
+}
+
+// DynamicToAPIServiceOptions converts a dynamic options value to API ServiceOptions
+func DynamicToAPIServiceOptions(optionsValue types.Dynamic) (api.ServiceOptions, error) {
+	if optionsValue.IsNull() || optionsValue.IsUnknown() {
 		return api.ServiceOptions{}, nil
 	}
 
 	options := make(api.ServiceOptions)
 
-	underlyingValue := m.Options.UnderlyingValue()
+	underlyingValue := optionsValue.UnderlyingValue()
 
 	if objValue, ok := underlyingValue.(basetypes.ObjectValue); ok {
 		attributes := objValue.Attributes()
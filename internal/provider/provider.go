@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -13,8 +18,14 @@ import (
 
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/datasources"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/identity"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/resources"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/transport"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -32,18 +43,37 @@ type CacheFlyProvider struct {
 type CacheFlyProviderModel struct {
 	APIToken types.String `tfsdk:"api_token"`
 	BaseURL  types.String `tfsdk:"base_url"`
-}
 
-// CacheFlyClient holds the SDK client with all service APIs
-type CacheFlyClient struct {
-	// Main SDK client with all services
-	Client *cachefly.Client
+	APITokenCommand        types.List   `tfsdk:"api_token_command"`
+	APITokenCommandTimeout types.String `tfsdk:"api_token_command_timeout"`
+
+	Profile               types.String `tfsdk:"profile"`
+	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
+
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64 `tfsdk:"requests_per_second"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
 
-	// Configuration for easy access
-	APIToken string
-	BaseURL  string
+	ReadOnly types.Bool `tfsdk:"read_only"`
+
+	ValidateCredentials types.Bool `tfsdk:"validate_credentials"`
 }
 
+// CacheFlyClient is the data passed to resources and data sources.
+type CacheFlyClient = providerdata.CacheFlyClient
+
 func (p *CacheFlyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "cachefly"
 	resp.Version = p.version
@@ -54,14 +84,98 @@ func (p *CacheFlyProvider) Schema(ctx context.Context, req provider.SchemaReques
 		MarkdownDescription: "The CacheFly provider allows you to manage CacheFly CDN resources using Terraform.",
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
-				MarkdownDescription: "The API token for authenticating with CacheFly. Can also be set with the `CACHEFLY_API_TOKEN` environment variable.",
+				MarkdownDescription: "The API token for authenticating with CacheFly. Can also be set with the `CACHEFLY_API_TOKEN` environment variable or read from a `profile` in the shared credentials file.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"api_token_command": schema.ListAttribute{
+				MarkdownDescription: "Command to run to get the API token, as a list of the program and its arguments, for example `[\"vault\", \"kv\", \"get\", \"-field=token\", \"secret/cachefly\"]`. " +
+					"The first line the command prints on stdout is used as the token. The command runs at most once per Terraform run. Ignored when `api_token` is set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"api_token_command_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time `api_token_command` may run, as a duration string such as `10s`. Defaults to `30s`.",
+				Optional:            true,
+			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "The base URL for the CacheFly API. Defaults to `https://api.cachefly.com/api/2.6`. Can also be set with the `CACHEFLY_BASE_URL` environment variable.",
+				MarkdownDescription: "The base URL for the CacheFly API. Defaults to `https://api.cachefly.com/api/2.6`. Can also be set with the `CACHEFLY_BASE_URL` environment variable or read from a `profile` in the shared credentials file.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in the shared credentials file to read `api_token` and `base_url` from. Can also be set with the `CACHEFLY_PROFILE` environment variable. " +
+					"A named profile takes precedence over the `CACHEFLY_API_TOKEN` and `CACHEFLY_BASE_URL` environment variables, but not over the `api_token` and `base_url` attributes. " +
+					"When no profile is named, the `default` profile is used as a last resort. A profile's `base_url` is only used when the token is also read from that profile.",
+				Optional: true,
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the shared credentials file. Can also be set with the `CACHEFLY_SHARED_CREDENTIALS_FILE` environment variable. Defaults to `~/.cachefly/credentials`. " +
+					"The file holds one `[profile]` section per CacheFly account, each with an `api_token` and optionally a `base_url`.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a failed API request is retried. Requests are retried when the API rate limits them (HTTP 429), and idempotent requests are also retried on HTTP 502/503/504 and connection errors. Defaults to `3`. Set to `0` to disable retries.",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Minimum time to wait between retries, as a duration string such as `500ms` or `1s`. The wait doubles on every attempt. A `Retry-After` header sent by the API replaces the computed wait. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait between retries, as a duration string such as `30s`. This also caps the wait requested by a `Retry-After` header. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of CacheFly API requests the provider sends at the same time, across all resources and data sources. Use this to stay under the API rate limit without lowering Terraform's `-parallelism`. Defaults to `0` (no limit).",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of CacheFly API requests the provider starts per second. Defaults to `0` (no limit).",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Block every API request that would change CacheFly objects. Creating, updating, deleting, activating or deactivating anything fails with an error before a request is sent, while refreshes, imports and data sources keep working. " +
+					"Use this for plans run with production credentials. Can also be set with the `CACHEFLY_READ_ONLY` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Check the API token when the provider is configured by looking up the user and account it belongs to. " +
+					"An invalid token or a token without the required permissions is then reported immediately instead of by the first resource that uses it. Defaults to `false`.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA certificate bundle that is trusted in addition to the system root CAs, for example the root of a TLS inspecting proxy.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates that are trusted in addition to the system root CAs.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the CacheFly API server certificate. **This makes the connection vulnerable to interception and should only be used for testing.** Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy to send API requests through, such as `http://proxy.example.com:3128`. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded client certificate for mutual TLS. Requires `client_key_file` or `client_key_pem`.",
 				Optional:            true,
 			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded private key of the mutual TLS client certificate.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Conflicts with `client_cert_file`.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the mutual TLS client certificate. Conflicts with `client_key_file`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 		},
 	}
 }
@@ -74,24 +188,87 @@ func (p *CacheFlyProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Set default values and get from environment if not set
-	apiToken := getConfigValue(config.APIToken, "CACHEFLY_API_TOKEN", "")
-	baseURL := getConfigValue(config.BaseURL, "CACHEFLY_BASE_URL", "https://api.cachefly.com/api/2.6")
+	// Settings such as api_token may come from other resources in the same
+	// run and be unknown while planning. Ask Terraform to defer everything
+	// that uses the provider until they are known, when it supports that.
+	if unknown := unknownConnectionAttributes(config); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Info(ctx, "Deferring CacheFly resources until the provider configuration is known", map[string]interface{}{
+				"unknown_attributes": unknown,
+			})
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+			return
+		}
+
+		for _, name := range unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown CacheFly Provider Configuration",
+				"The provider cannot configure the CacheFly API client because "+name+" is not known until apply. "+
+					"Either apply the resources it depends on first, for example with -target, or use a Terraform version "+
+					"that supports deferred actions so CacheFly resources are planned in a later round.",
+			)
+		}
+		return
+	}
+
+	creds := resolveCredentials(ctx, config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Validate required configuration
-	if apiToken == "" {
+	if creds.APIToken == "" {
 		resp.Diagnostics.AddError(
 			"Missing API Token",
 			"The CacheFly API token is required but was not found. "+
-				"Please set it in the provider configuration or via the CACHEFLY_API_TOKEN environment variable.",
+				"Please set api_token or api_token_command in the provider configuration, use the CACHEFLY_API_TOKEN environment variable, "+
+				"or in a profile of the shared credentials file.",
+		)
+		return
+	}
+
+	retryConfig := retryConfigFromModel(config, &resp.Diagnostics)
+	limitConfig := limitConfigFromModel(config, &resp.Diagnostics)
+	baseConfig := baseConfigFromModel(config, &resp.Diagnostics)
+	readOnly := readOnlyFromModel(config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	baseTransport, err := transport.NewBaseTransport(baseConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid HTTP Client Configuration",
+			"Could not configure the connection to the CacheFly API: "+err.Error(),
 		)
 		return
 	}
 
+	// All resources and data sources share this HTTP client, so every API
+	// call goes through the same limiter and retry handling. Retries sit on
+	// top of the limiter so that each attempt waits for its own slot, and
+	// tracing sits closest to the wire so every attempt is logged.
+	httpClient := &http.Client{
+		Transport: transport.NewRetryTransport(
+			transport.NewLimitTransport(transport.NewTraceTransport(baseTransport), limitConfig),
+			retryConfig,
+		),
+	}
+
+	// The read-only guard wraps everything else so blocked requests are
+	// neither retried nor counted against the limits.
+	if readOnly {
+		httpClient.Transport = transport.NewReadOnlyTransport(httpClient.Transport)
+	}
+
 	// Create CacheFly SDK client using the proper constructor
 	cacheflyClient := cachefly.NewClient(
-		cachefly.WithToken(apiToken),
-		cachefly.WithBaseURL(baseURL),
+		cachefly.WithToken(creds.APIToken),
+		cachefly.WithBaseURL(creds.BaseURL),
+		cachefly.WithHTTPClient(httpClient),
 	)
 
 	if cacheflyClient == nil {
@@ -102,14 +279,34 @@ func (p *CacheFlyProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	client := cacheflyClient
+	client := &CacheFlyClient{
+		Client:         cacheflyClient,
+		API:            apiclient.New(httpClient, creds.BaseURL, creds.APIToken),
+		Identity:       identity.NewClient(httpClient, creds.BaseURL, creds.APIToken),
+		ServiceOptions: serviceoptions.NewClient(httpClient, creds.BaseURL, creds.APIToken),
+		APIToken:       creds.APIToken,
+		BaseURL:        creds.BaseURL,
+	}
+
+	if config.ValidateCredentials.ValueBool() {
+		validateCredentials(ctx, client.Identity, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// client available to resources and data sources
 	resp.DataSourceData = client
 	resp.ResourceData = client
 
 	tflog.Info(ctx, "Successfully configured CacheFly provider", map[string]interface{}{
-		"base_url": baseURL,
+		"base_url":                creds.BaseURL,
+		"credentials_source":      creds.Source,
+		"max_retries":             retryConfig.MaxRetries,
+		"max_concurrent_requests": limitConfig.MaxConcurrentRequests,
+		"requests_per_second":     limitConfig.RequestsPerSecond,
+		"proxy_url":               baseConfig.ProxyURL,
+		"read_only":               readOnly,
 	})
 }
 
@@ -128,6 +325,7 @@ func (p *CacheFlyProvider) Resources(ctx context.Context) []func() resource.Reso
 func (p *CacheFlyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.NewServiceDataSource,
+		datasources.NewServicesDataSource,
 		datasources.NewServiceDomainDataSource,
 		datasources.NewServiceDomainsDataSource,
 		datasources.NewOriginDataSource,
@@ -135,6 +333,7 @@ func (p *CacheFlyProvider) DataSources(ctx context.Context) []func() datasource.
 		datasources.NewLogTargetsDataSource,
 		datasources.NewUsersDataSource,
 		datasources.NewDeliveryRegionsDataSource,
+		datasources.NewCurrentIdentityDataSource,
 	}
 }
 
@@ -158,3 +357,229 @@ func getConfigValue(configValue types.String, envVar, defaultValue string) strin
 
 	return defaultValue
 }
+
+// unknownConnectionAttributes returns the names of the attributes needed to
+// connect to the API whose values are not known yet. read_only is included:
+// it decides whether the client may change anything, so an unknown value
+// must not fall back to allowing writes.
+func unknownConnectionAttributes(config CacheFlyProviderModel) []string {
+	attributes := []struct {
+		name    string
+		unknown bool
+	}{
+		{"api_token", config.APIToken.IsUnknown()},
+		{"api_token_command", config.APITokenCommand.IsUnknown() || hasUnknownElement(config.APITokenCommand)},
+		{"base_url", config.BaseURL.IsUnknown()},
+		{"profile", config.Profile.IsUnknown()},
+		{"shared_credentials_file", config.SharedCredentialsFile.IsUnknown()},
+		{"read_only", config.ReadOnly.IsUnknown()},
+	}
+
+	var unknown []string
+	for _, attribute := range attributes {
+		if attribute.unknown {
+			unknown = append(unknown, attribute.name)
+		}
+	}
+
+	return unknown
+}
+
+func hasUnknownElement(list types.List) bool {
+	for _, element := range list.Elements() {
+		if element.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+// retryConfigFromModel builds the retry settings from the provider
+// configuration, falling back to the defaults for unset attributes.
+func retryConfigFromModel(config CacheFlyProviderModel, diags *diag.Diagnostics) transport.RetryConfig {
+	retryConfig := transport.DefaultRetryConfig()
+
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Retry Configuration",
+				"max_retries must be zero or greater.",
+			)
+		}
+		retryConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if d, ok := parseDurationAttribute(config.RetryMinBackoff, path.Root("retry_min_backoff"), diags); ok {
+		retryConfig.MinBackoff = d
+	}
+
+	if d, ok := parseDurationAttribute(config.RetryMaxBackoff, path.Root("retry_max_backoff"), diags); ok {
+		retryConfig.MaxBackoff = d
+	}
+
+	if retryConfig.MinBackoff > retryConfig.MaxBackoff {
+		diags.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Retry Configuration",
+			"retry_min_backoff ("+retryConfig.MinBackoff.String()+") must not be greater than retry_max_backoff ("+retryConfig.MaxBackoff.String()+").",
+		)
+	}
+
+	return retryConfig
+}
+
+// limitConfigFromModel builds the request limiter settings from the provider
+// configuration. Unset attributes leave the corresponding limit disabled.
+func limitConfigFromModel(config CacheFlyProviderModel, diags *diag.Diagnostics) transport.LimitConfig {
+	var limitConfig transport.LimitConfig
+
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		if config.MaxConcurrentRequests.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Request Limit",
+				"max_concurrent_requests must be zero (no limit) or greater.",
+			)
+		}
+		limitConfig.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		if config.RequestsPerSecond.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Request Limit",
+				"requests_per_second must be zero (no limit) or greater.",
+			)
+		}
+		limitConfig.RequestsPerSecond = int(config.RequestsPerSecond.ValueInt64())
+	}
+
+	return limitConfig
+}
+
+// baseConfigFromModel builds the TLS and proxy settings from the provider
+// configuration.
+func baseConfigFromModel(config CacheFlyProviderModel, diags *diag.Diagnostics) transport.BaseConfig {
+	baseConfig := transport.BaseConfig{
+		CACertFile:         config.CACertFile.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		ProxyURL:           config.ProxyURL.ValueString(),
+		ClientCertFile:     config.ClientCertFile.ValueString(),
+		ClientKeyFile:      config.ClientKeyFile.ValueString(),
+		ClientCertPEM:      config.ClientCertPEM.ValueString(),
+		ClientKeyPEM:       config.ClientKeyPEM.ValueString(),
+	}
+
+	if baseConfig.ClientCertFile != "" && baseConfig.ClientCertPEM != "" {
+		diags.AddAttributeError(
+			path.Root("client_cert_pem"),
+			"Conflicting Client Certificate Configuration",
+			"Only one of client_cert_file and client_cert_pem can be set.",
+		)
+	}
+
+	if baseConfig.ClientKeyFile != "" && baseConfig.ClientKeyPEM != "" {
+		diags.AddAttributeError(
+			path.Root("client_key_pem"),
+			"Conflicting Client Certificate Configuration",
+			"Only one of client_key_file and client_key_pem can be set.",
+		)
+	}
+
+	if baseConfig.InsecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the provider does not verify the certificate of the CacheFly API. "+
+				"Anyone able to intercept the connection can read your API token and modify requests. "+
+				"Use ca_cert_file or ca_cert_pem to trust a private CA instead.",
+		)
+	}
+
+	return baseConfig
+}
+
+// validateCredentials looks up the identity behind the API token and turns
+// authentication failures into precise diagnostics.
+func validateCredentials(ctx context.Context, identityClient *identity.Client, diags *diag.Diagnostics) {
+	id, err := identityClient.Get(ctx)
+	if err != nil {
+		switch {
+		case apierrors.IsKind(err, apierrors.KindUnauthorized):
+			diags.AddAttributeError(
+				path.Root("api_token"),
+				"Invalid CacheFly API Token",
+				"The CacheFly API rejected the API token. Check that api_token, api_token_command, the selected profile "+
+					"or CACHEFLY_API_TOKEN holds a valid token for the configured base_url.\n\n"+err.Error(),
+			)
+		case apierrors.IsKind(err, apierrors.KindForbidden):
+			diags.AddAttributeError(
+				path.Root("api_token"),
+				"Insufficient CacheFly API Permissions",
+				"The API token is valid but is not allowed to read its own user and account. "+
+					"Grant the token's user the required permissions or use a different token.\n\n"+err.Error(),
+			)
+		default:
+			diags.Append(apierrors.Diagnostic(
+				"Error Validating CacheFly Credentials",
+				"Could not look up the user and account of the API token",
+				err,
+			))
+		}
+		return
+	}
+
+	tflog.Info(ctx, "Validated CacheFly credentials", map[string]interface{}{
+		"account_id": id.AccountID,
+		"username":   id.Username,
+	})
+}
+
+// readOnlyFromModel reports whether read-only mode is enabled by the
+// read_only attribute or the CACHEFLY_READ_ONLY environment variable. An
+// unknown read_only defers configuration before this is reached.
+func readOnlyFromModel(config CacheFlyProviderModel, diags *diag.Diagnostics) bool {
+	if !config.ReadOnly.IsNull() {
+		return config.ReadOnly.ValueBool()
+	}
+
+	value := os.Getenv("CACHEFLY_READ_ONLY")
+	if value == "" {
+		return false
+	}
+
+	readOnly, err := strconv.ParseBool(value)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Invalid Read-Only Configuration",
+			"CACHEFLY_READ_ONLY must be true or false, got: "+value,
+		)
+		return false
+	}
+
+	return readOnly
+}
+
+// parseDurationAttribute parses a duration string attribute. It returns false
+// when the attribute is not set or could not be parsed.
+func parseDurationAttribute(value types.String, attrPath path.Path, diags *diag.Diagnostics) (time.Duration, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Duration",
+			"Expected a non-negative duration such as \"500ms\", \"1s\" or \"2m\", got: "+value.ValueString(),
+		)
+		return 0, false
+	}
+
+	return d, true
+}
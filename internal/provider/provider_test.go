@@ -2,12 +2,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +53,25 @@ func TestProviderSchema(t *testing.T) {
 	attrs := schemaResp.Schema.Attributes
 	assert.Contains(t, attrs, "api_token", "Schema should contain 'api_token' attribute")
 	assert.Contains(t, attrs, "base_url", "Schema should contain 'base_url' attribute")
+	assert.Contains(t, attrs, "max_retries", "Schema should contain 'max_retries' attribute")
+	assert.Contains(t, attrs, "retry_min_backoff", "Schema should contain 'retry_min_backoff' attribute")
+	assert.Contains(t, attrs, "retry_max_backoff", "Schema should contain 'retry_max_backoff' attribute")
+	assert.Contains(t, attrs, "max_concurrent_requests", "Schema should contain 'max_concurrent_requests' attribute")
+	assert.Contains(t, attrs, "requests_per_second", "Schema should contain 'requests_per_second' attribute")
+	assert.Contains(t, attrs, "api_token_command", "Schema should contain 'api_token_command' attribute")
+	assert.Contains(t, attrs, "api_token_command_timeout", "Schema should contain 'api_token_command_timeout' attribute")
+	assert.Contains(t, attrs, "profile", "Schema should contain 'profile' attribute")
+	assert.Contains(t, attrs, "shared_credentials_file", "Schema should contain 'shared_credentials_file' attribute")
+	assert.Contains(t, attrs, "ca_cert_file", "Schema should contain 'ca_cert_file' attribute")
+	assert.Contains(t, attrs, "ca_cert_pem", "Schema should contain 'ca_cert_pem' attribute")
+	assert.Contains(t, attrs, "insecure_skip_verify", "Schema should contain 'insecure_skip_verify' attribute")
+	assert.Contains(t, attrs, "proxy_url", "Schema should contain 'proxy_url' attribute")
+	assert.Contains(t, attrs, "client_cert_file", "Schema should contain 'client_cert_file' attribute")
+	assert.Contains(t, attrs, "client_key_file", "Schema should contain 'client_key_file' attribute")
+	assert.Contains(t, attrs, "client_cert_pem", "Schema should contain 'client_cert_pem' attribute")
+	assert.Contains(t, attrs, "client_key_pem", "Schema should contain 'client_key_pem' attribute")
+	assert.Contains(t, attrs, "read_only", "Schema should contain 'read_only' attribute")
+	assert.Contains(t, attrs, "validate_credentials", "Schema should contain 'validate_credentials' attribute")
 
 	// Verify api_token is marked as sensitive
 	if apiTokenAttr, ok := attrs["api_token"].(schema.StringAttribute); ok {
@@ -96,6 +120,123 @@ func TestProviderConfigure(t *testing.T) {
 			`,
 			expectError: false,
 		},
+		{
+			name: "custom retry settings",
+			config: `
+				provider "cachefly" {
+					api_token         = "test-token"
+					max_retries       = 5
+					retry_min_backoff = "500ms"
+					retry_max_backoff = "10s"
+				}
+			`,
+			expectError: false,
+		},
+		{
+			name: "request limits",
+			config: `
+				provider "cachefly" {
+					api_token               = "test-token"
+					max_concurrent_requests = 4
+					requests_per_second     = 10
+				}
+			`,
+			expectError: false,
+		},
+		{
+			name: "negative request limit",
+			config: `
+				provider "cachefly" {
+					api_token               = "test-token"
+					max_concurrent_requests = -1
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Invalid Request Limit",
+		},
+		{
+			name: "invalid retry backoff",
+			config: `
+				provider "cachefly" {
+					api_token         = "test-token"
+					retry_min_backoff = "soon"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Invalid Duration",
+		},
+		{
+			name: "retry min backoff greater than max",
+			config: `
+				provider "cachefly" {
+					api_token         = "test-token"
+					retry_min_backoff = "1m"
+					retry_max_backoff = "10s"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Invalid Retry Configuration",
+		},
+		{
+			name: "missing shared credentials file",
+			config: `
+				provider "cachefly" {
+					shared_credentials_file = "/nonexistent/cachefly/credentials"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Invalid Shared Credentials File",
+		},
+		{
+			name: "invalid read only environment variable",
+			config: `
+				provider "cachefly" {
+					api_token = "test-token"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			envVars: map[string]string{
+				"CACHEFLY_READ_ONLY": "sometimes",
+			},
+			expectError: true,
+			errorMsg:    "Invalid Read-Only Configuration",
+		},
+		{
+			name: "invalid proxy url",
+			config: `
+				provider "cachefly" {
+					api_token = "test-token"
+					proxy_url = "proxy:3128"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Invalid HTTP Client Configuration",
+		},
+		{
+			name: "conflicting client certificate settings",
+			config: `
+				provider "cachefly" {
+					api_token        = "test-token"
+					client_cert_file = "client.pem"
+					client_cert_pem  = "-----BEGIN CERTIFICATE-----"
+				}
+
+				data "cachefly_delivery_regions" "test" {}
+			`,
+			expectError: true,
+			errorMsg:    "Conflicting Client Certificate Configuration",
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,6 +271,126 @@ func TestProviderConfigure(t *testing.T) {
 	}
 }
 
+// TestProviderConfigure_ValidateCredentials tests that validate_credentials
+// reports a rejected token when the provider is configured.
+func TestProviderConfigure_ValidateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Invalid token"}`))
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "cachefly" {
+						api_token            = "invalid-token"
+						base_url             = %q
+						validate_credentials = true
+						max_retries          = 0
+					}
+
+					data "cachefly_delivery_regions" "test" {}
+				`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid CacheFly API Token"),
+			},
+		},
+	})
+}
+
+// testProviderConfigureRequest builds a ConfigureRequest where every
+// attribute is null except the given values.
+func testProviderConfigureRequest(t *testing.T, values map[string]tftypes.Value, deferralAllowed bool) provider.ConfigureRequest {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &provider.SchemaResponse{}
+	(&CacheFlyProvider{version: "test"}).Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("provider schema is not an object")
+	}
+
+	attributes := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{
+			DeferralAllowed: deferralAllowed,
+		},
+	}
+}
+
+// TestProviderConfigure_UnknownValues tests that unknown connection settings
+// defer the provider when Terraform allows it.
+func TestProviderConfigure_UnknownValues(t *testing.T) {
+	ctx := context.Background()
+	p := &CacheFlyProvider{version: "test"}
+
+	unknownToken := map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}
+	unknownBaseURL := map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "test-token"),
+		"base_url":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}
+
+	// An unknown read_only must not fall back to allowing writes.
+	unknownReadOnly := map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "test-token"),
+		"read_only": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	}
+
+	for name, values := range map[string]map[string]tftypes.Value{"api_token": unknownToken, "base_url": unknownBaseURL, "read_only": unknownReadOnly} {
+		t.Run(name+" deferred", func(t *testing.T) {
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, testProviderConfigureRequest(t, values, true), resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+			if assert.NotNil(t, resp.Deferred) {
+				assert.Equal(t, provider.DeferredReasonProviderConfigUnknown, resp.Deferred.Reason)
+			}
+			assert.Nil(t, resp.ResourceData)
+			assert.Nil(t, resp.DataSourceData)
+		})
+
+		t.Run(name+" without deferral support", func(t *testing.T) {
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, testProviderConfigureRequest(t, values, false), resp)
+
+			assert.Nil(t, resp.Deferred)
+			if assert.True(t, resp.Diagnostics.HasError()) {
+				assert.Equal(t, "Unknown CacheFly Provider Configuration", resp.Diagnostics.Errors()[0].Summary())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), name+" is not known until apply")
+			}
+		})
+	}
+
+	t.Run("known values are not deferred", func(t *testing.T) {
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, testProviderConfigureRequest(t, map[string]tftypes.Value{
+			"api_token": tftypes.NewValue(tftypes.String, "test-token"),
+		}, true), resp)
+
+		assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+		assert.Nil(t, resp.Deferred)
+		assert.NotNil(t, resp.ResourceData)
+	})
+}
+
 // TestGetConfigValue tests the helper function
 func TestGetConfigValue(t *testing.T) {
 	tests := []struct {
@@ -202,7 +463,7 @@ func TestProviderDataSources(t *testing.T) {
 
 	dataSources := provider.DataSources(ctx)
 
-	expectedDataSourceCount := 6 //
+	expectedDataSourceCount := 10
 	assert.Len(t, dataSources, expectedDataSourceCount, "Should have expected number of data sources")
 
 	// Test that each data source can be instantiated
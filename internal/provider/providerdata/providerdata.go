@@ -0,0 +1,32 @@
+// Package providerdata defines the data the provider hands to resources and
+// data sources when it is configured.
+package providerdata
+
+import (
+	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/identity"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+// CacheFlyClient holds the SDK client with all service APIs, along with the
+// clients for the endpoints the SDK does not cover. All of them share one
+// HTTP client.
+type CacheFlyClient struct {
+	// Main SDK client with all services
+	Client *cachefly.Client
+
+	// API sends requests the SDK has no method for.
+	API *apiclient.Client
+
+	// Identity looks up the account and user behind the API token.
+	Identity *identity.Client
+
+	// ServiceOptions fetches and caches the options metadata of services.
+	ServiceOptions *serviceoptions.Client
+
+	// Configuration for easy access
+	APIToken string
+	BaseURL  string
+}
@@ -3,7 +3,6 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -16,7 +15,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -135,16 +136,16 @@ func (r *CertificateResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 // Create creates the resource and sets the initial Terraform state
@@ -167,10 +168,11 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 
 	cert, err := r.client.Certificates.Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly Certificate",
-			"Could not create certificate, unexpected error: "+err.Error(),
-		)
+			"Could not create certificate, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -191,13 +193,14 @@ func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest
 
 	cert, err := r.client.Certificates.GetByID(ctx, certID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Certificate",
-				"Could not read certificate with ID "+certID+": "+err.Error(),
-			)
+				"Could not read certificate with ID "+certID,
+				err,
+			))
 		}
 		return
 	}
@@ -248,10 +251,11 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	err := r.client.Certificates.Delete(ctx, certID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deleting CacheFly Certificate",
-			"Could not delete certificate with ID "+certID+": "+err.Error(),
-		)
+			"Could not delete certificate with ID "+certID,
+			err,
+		))
 		return
 	}
 }
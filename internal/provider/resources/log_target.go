@@ -4,7 +4,6 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -18,7 +17,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // satisfy framework interfaces.
@@ -153,16 +154,16 @@ func (r *LogTargetResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *LogTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -234,10 +235,11 @@ func (r *LogTargetResource) Create(ctx context.Context, req resource.CreateReque
 
 	logTarget, err := r.client.LogTargets.Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly Log Target",
-			"Could not create log target, unexpected error: "+err.Error(),
-		)
+			"Could not create log target, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -267,10 +269,11 @@ func (r *LogTargetResource) Create(ctx context.Context, req resource.CreateReque
 		var err error
 		logTarget, err = r.client.LogTargets.SetLogging(ctx, logTarget.ID, setLoggingRequest)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Enabling Logging",
-				"Could not enable logging, unexpected error: "+err.Error(),
-			)
+				"Could not enable logging, unexpected error",
+				err,
+			))
 			return
 		}
 	}
@@ -289,13 +292,14 @@ func (r *LogTargetResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	logTarget, err := r.client.LogTargets.GetByID(ctx, data.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "API error 404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Log Target",
-				"Could not read log target ID "+data.ID.ValueString()+": "+err.Error(),
-			)
+				"Could not read log target ID "+data.ID.ValueString(),
+				err,
+			))
 		}
 		return
 	}
@@ -380,10 +384,11 @@ func (r *LogTargetResource) Update(ctx context.Context, req resource.UpdateReque
 
 	logTarget, err := r.client.LogTargets.UpdateByID(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Updating CacheFly Log Target",
-			"Could not update log target, unexpected error: "+err.Error(),
-		)
+			"Could not update log target, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -432,10 +437,11 @@ func (r *LogTargetResource) Update(ctx context.Context, req resource.UpdateReque
 		var err error
 		logTarget, err = r.client.LogTargets.SetLogging(ctx, data.ID.ValueString(), setLoggingRequest)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Enabling Logging",
-				"Could not enable logging, unexpected error: "+err.Error(),
-			)
+				"Could not enable logging, unexpected error",
+				err,
+			))
 			return
 		}
 	}
@@ -459,19 +465,21 @@ func (r *LogTargetResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 
 	if _, err := r.client.LogTargets.SetLogging(ctx, data.ID.ValueString(), setLoggingRequest); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Disabling Logging for Log Target",
-			"Could not disable logging prior to deletion: "+err.Error(),
-		)
+			"Could not disable logging prior to deletion",
+			err,
+		))
 		return
 	}
 
 	err := r.client.LogTargets.DeleteByID(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deleting CacheFly Log Target",
-			"Could not delete log target, unexpected error: "+err.Error(),
-		)
+			"Could not delete log target, unexpected error",
+			err,
+		))
 		return
 	}
 }
@@ -4,7 +4,6 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,7 +17,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // satisfy framework interfaces.
@@ -163,16 +164,16 @@ func (r *OriginResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *OriginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -234,10 +235,11 @@ func (r *OriginResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	origin, err := r.client.Origins.Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly Origin",
-			"Could not create origin, unexpected error: "+err.Error(),
-		)
+			"Could not create origin, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -256,13 +258,14 @@ func (r *OriginResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	origin, err := r.client.Origins.GetByID(ctx, data.ID.ValueString(), "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Origin",
-				"Could not read origin ID "+data.ID.ValueString()+": "+err.Error(),
-			)
+				"Could not read origin ID "+data.ID.ValueString(),
+				err,
+			))
 		}
 		return
 	}
@@ -339,10 +342,11 @@ func (r *OriginResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	origin, err := r.client.Origins.UpdateByID(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Updating CacheFly Origin",
-			"Could not update origin, unexpected error: "+err.Error(),
-		)
+			"Could not update origin, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -361,10 +365,11 @@ func (r *OriginResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	err := r.client.Origins.Delete(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deleting CacheFly Origin",
-			"Could not delete origin, unexpected error: "+err.Error(),
-		)
+			"Could not delete origin, unexpected error",
+			err,
+		))
 		return
 	}
 
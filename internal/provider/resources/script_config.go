@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -18,7 +17,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -120,16 +121,16 @@ func (r *ScriptConfigResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 func (r *ScriptConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data models.ScriptConfigModel
@@ -143,10 +144,11 @@ func (r *ScriptConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	config, err := r.client.ScriptConfigs.Create(ctx, *createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly Script Config",
-			"Could not create script config, unexpected error: "+err.Error(),
-		)
+			"Could not create script config, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -155,10 +157,11 @@ func (r *ScriptConfigResource) Create(ctx context.Context, req resource.CreateRe
 	if shouldActivate {
 		activatedConfig, err := r.client.ScriptConfigs.ActivateByID(ctx, config.ID)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Activating CacheFly Script Config",
-				"Script config was created but could not be activated: "+err.Error(),
-			)
+				"Script config was created but could not be activated",
+				err,
+			))
 			return
 		}
 		config = activatedConfig
@@ -186,13 +189,14 @@ func (r *ScriptConfigResource) Read(ctx context.Context, req resource.ReadReques
 
 	config, err := r.client.ScriptConfigs.GetByID(ctx, configID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Script Config",
-				"Could not read script config with ID "+configID+": "+err.Error(),
-			)
+				"Could not read script config with ID "+configID,
+				err,
+			))
 		}
 		return
 	}
@@ -243,10 +247,11 @@ func (r *ScriptConfigResource) Update(ctx context.Context, req resource.UpdateRe
 
 	config, err := r.client.ScriptConfigs.UpdateByID(ctx, configID, *updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Updating CacheFly Script Config",
-			"Could not update script config with ID "+configID+": "+err.Error(),
-		)
+			"Could not update script config with ID "+configID,
+			err,
+		))
 		return
 	}
 
@@ -262,10 +267,11 @@ func (r *ScriptConfigResource) Update(ctx context.Context, req resource.UpdateRe
 			if !data.Activated.ValueBool() {
 				action = "deactivat"
 			}
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				fmt.Sprintf("Error %sing CacheFly Script Config", action),
-				fmt.Sprintf("Could not %se script config with ID %s: %s", action, configID, err.Error()),
-			)
+				fmt.Sprintf("Could not %se script config with ID %s", action, configID),
+				err,
+			))
 			return
 		}
 	}
@@ -289,10 +295,11 @@ func (r *ScriptConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 	// Deactivate script config (there is no "delete" operation)
 	_, err := r.client.ScriptConfigs.DeactivateByID(ctx, configID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deactivating CacheFly Script Config",
-			"Could not deactivate script config with ID "+configID+": "+err.Error(),
-		)
+			"Could not deactivate script config with ID "+configID,
+			err,
+		))
 		return
 	}
 }
@@ -2,29 +2,43 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/lookup"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
 )
 
 // satisfy framework interfaces.
 var (
-	_ resource.Resource                = &ServiceResource{}
-	_ resource.ResourceWithConfigure   = &ServiceResource{}
-	_ resource.ResourceWithImportState = &ServiceResource{}
+	_ resource.Resource                   = &ServiceResource{}
+	_ resource.ResourceWithConfigure      = &ServiceResource{}
+	_ resource.ResourceWithImportState    = &ServiceResource{}
+	_ resource.ResourceWithModifyPlan     = &ServiceResource{}
+	_ resource.ResourceWithValidateConfig = &ServiceResource{}
 )
 
 func NewServiceResource() resource.Resource {
@@ -33,7 +47,9 @@ func NewServiceResource() resource.Resource {
 
 // ServiceResource defines the resource implementation.
 type ServiceResource struct {
-	client *cachefly.Client
+	client  *cachefly.Client
+	api     *apiclient.Client
+	options *serviceoptions.Client
 }
 
 func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,7 +75,7 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"unique_name": schema.StringAttribute{
-				Description: "The unique name of the service used in URLs and configurations. Must be unique across all services.",
+				Description: "The unique name of the service used in URLs and configurations. Must be unique across all services. The service update request does not include it, so changing it replaces the service.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -77,8 +93,15 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:    true,
 			},
 			"configuration_mode": schema.StringAttribute{
+				MarkdownDescription: "The configuration mode for the service, `API_RULES_AND_OPTIONS` or `MIXED_RULES_AND_OPTIONS`. " +
+					"The mode is switched before options are applied. Options that are not available in the planned mode are reported while planning an update, and before the options are applied when the service is created. " +
+					"If unset, the mode is left as it is on CacheFly.",
 				Description: "The configuration mode for the service.",
+				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"tls_profile": schema.StringAttribute{
 				Description: "The TLS profile to use for SSL connections.",
@@ -89,11 +112,79 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:    true,
 			},
 			"options": schema.DynamicAttribute{
-				MarkdownDescription: `Service options as a map.`,
+				MarkdownDescription: "Service options as a map. See [Options](#options) for full option catalog, types, allowed values, and constraints. Options in the catalog are validated while planning.",
 				Description:         "Service options configuration as key-value pairs. Each option follows the enabled/value structure for feature options.",
 				Optional:            true,
 				// Computed:    true,
 			},
+			"typed_options": schema.SingleNestedAttribute{
+				MarkdownDescription: "Service options as typed attributes, one per option in [Options](#options). Boolean options are booleans, options with the enabled/value structure are objects with `enabled` and `value`, and object options are objects with `enabled` and their fields. " +
+					"Options can be set here or in `options`, but not in both. Options missing from the provider's catalog can only be set in `options`.",
+				Optional:   true,
+				Attributes: serviceoptions.Builtin().ResourceAttributes(),
+			},
+			"options_removal_behavior": schema.StringAttribute{
+				MarkdownDescription: "What happens to an option on CacheFly when its key is removed from `options`. " +
+					"`reset_to_default` (the default) restores the option's default value, or leaves the option as it is with a warning if the default is unknown. " +
+					"`disable` switches the option off. `ignore` leaves the option as it is on CacheFly.",
+				Optional: true,
+			},
+			"source_service_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a service to copy when this service is created. Its options, TLS profile and delivery region are copied, " +
+					"and `options`, `tls_profile` and `delivery_region` in the configuration override them. " +
+					"Changing or removing it later does not change the service.",
+				Optional: true,
+			},
+			"source_baseline": schema.SingleNestedAttribute{
+				MarkdownDescription: "What was copied from `source_service_id` when the service was created. Later changes to the source service are reported while planning.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"options": schema.DynamicAttribute{
+						Description: "The options copied from the source service.",
+						Computed:    true,
+					},
+					"tls_profile": schema.StringAttribute{
+						Description: "The TLS profile of the source service.",
+						Computed:    true,
+					},
+					"delivery_region": schema.StringAttribute{
+						Description: "The delivery region of the source service.",
+						Computed:    true,
+					},
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing deactivated service with the same `unique_name` instead of failing to create a new one, for example a service that was deactivated on destroy. An active service with the same `unique_name` is reported as already existing. " +
+					"The adopted service is reactivated and its description, SSL settings, TLS profile, delivery region and options are set from the configuration. Defaults to `false`.",
+				Optional: true,
+			},
+			"deletion_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the service on CacheFly when the resource is destroyed. " +
+					"`deactivate` (the default) deactivates the service. `delete` deletes the service, falling back to deactivation if the API does not support deleting services. " +
+					"`abandon` only removes the service from the Terraform state.",
+				Optional: true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying or replacing the service is blocked. Plans that destroy or replace it fail. Set it to false and apply before destroying the service. Defaults to `false`.",
+				Optional:            true,
+			},
+			"wait_for_deployment": schema.BoolAttribute{
+				MarkdownDescription: "Whether create and update wait until the API reports the planned status and the option values it accepted for the service. " +
+					"Sensitive fields such as secret keys, and options or fields the API does not return, are not waited for. " +
+					"The service is polled with backoff until then, or until the timeout in the `timeouts` block expires. Defaults to `false`.",
+				Optional: true,
+			},
+			"exclusive_options": schema.BoolAttribute{
+				MarkdownDescription: "Whether `options` and `typed_options` hold every option of the service. When true, options that differ from their default but are not in either show up as drift and are reset according to `options_removal_behavior` on the next apply. Defaults to `false`.",
+				Optional:            true,
+			},
+			"unmanaged_options": schema.DynamicAttribute{
+				MarkdownDescription: "Options that differ from their default on CacheFly but are not in `options` or `typed_options`, for example options changed in the CacheFly portal.",
+				Computed:            true,
+			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "The current status of the service. Set this to 'ACTIVE' to activate the service or 'DEACTIVATED' to deactivate it.",
 				Description:         "The current status of the service.",
@@ -112,6 +203,21 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "How long create and update wait for the deployment when `wait_for_deployment` is true. Durations such as `30m` or `1h`; both default to `20m`.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Description: "Timeout for waiting after create.",
+						Optional:    true,
+					},
+					"update": schema.StringAttribute{
+						Description: "Timeout for waiting after update.",
+						Optional:    true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -121,44 +227,266 @@ func (r *ServiceResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.options = providerData.ServiceOptions
 }
 
-func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data models.ServiceResourceModel
+// ValidateConfig checks the options against the built-in catalog, so typos and
+// malformed values are reported without contacting the API. Options missing
+// from the catalog are left for the API to validate, and fields missing from
+// it are only warned about.
+func (r *ServiceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var options types.Dynamic
+	var typedOptions types.Object
+	var removalBehavior types.String
+	var configurationMode types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options"), &options)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("typed_options"), &typedOptions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options_removal_behavior"), &removalBehavior)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("configuration_mode"), &configurationMode)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	var exclusive types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("exclusive_options"), &exclusive)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	createReq := api.CreateServiceRequest{
-		Name:        data.Name.ValueString(),
-		UniqueName:  data.UniqueName.ValueString(),
-		Description: data.Description.ValueString(),
+	if exclusive.ValueBool() && removalBehavior.ValueString() == optionsRemovalIgnore {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("options_removal_behavior"),
+			"Invalid Options Removal Behavior",
+			fmt.Sprintf("%q cannot be used with exclusive_options, since unmanaged options would show up as drift on every plan.", optionsRemovalIgnore),
+		)
+	}
+
+	var deletionPolicy types.String
+	var timeouts *models.ServiceTimeoutsModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeouts"), &timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateTimeouts(timeouts, &resp.Diagnostics)
+
+	if !deletionPolicy.IsNull() && !deletionPolicy.IsUnknown() {
+		switch deletionPolicy.ValueString() {
+		case deletionPolicyDeactivate, deletionPolicyDelete, deletionPolicyAbandon:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_policy"),
+				"Invalid Deletion Policy",
+				fmt.Sprintf("Expected one of %q, %q or %q, got %q.",
+					deletionPolicyDeactivate, deletionPolicyDelete, deletionPolicyAbandon, deletionPolicy.ValueString()),
+			)
+		}
+	}
+
+	if !removalBehavior.IsNull() && !removalBehavior.IsUnknown() {
+		switch removalBehavior.ValueString() {
+		case optionsRemovalResetToDefault, optionsRemovalDisable, optionsRemovalIgnore:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("options_removal_behavior"),
+				"Invalid Options Removal Behavior",
+				fmt.Sprintf("Expected one of %q, %q or %q, got %q.",
+					optionsRemovalResetToDefault, optionsRemovalDisable, optionsRemovalIgnore, removalBehavior.ValueString()),
+			)
+		}
+	}
+
+	validateConfigurationMode(configurationMode, &resp.Diagnostics)
+
+	values, paths, ok := configuredOptions(options, typedOptions, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	builtinOpts := serviceoptions.ValidateOptions{ConfigurationMode: configurationMode.ValueString()}
+	for _, problem := range serviceoptions.Builtin().Validate(values, builtinOpts) {
+		// The built-in catalog may not list every field the API accepts,
+		// so fields it does not know are left for the API to judge.
+		if problem.Kind == serviceoptions.ProblemUnsupportedField {
+			resp.Diagnostics.AddAttributeWarning(
+				paths.problem(problem),
+				"Unrecognized Service Option Field",
+				problem.Message+" The field is not in the provider's options catalog, which may be out of date, so it is sent to the API as configured.",
+			)
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			paths.problem(problem),
+			"Invalid Service Option",
+			problem.Message,
+		)
+	}
+}
+
+// ModifyPlan rejects destroying or replacing a service with deletion
+// protection, warns when a unique_name change replaces an existing service,
+// and validates the planned options of an existing service against the options
+// metadata of that service and its planned configuration mode, which also
+// covers options missing from the built-in catalog and options the service
+// does not support. New services have no metadata yet and are only checked by
+// ValidateConfig here; Create checks their options against the configuration
+// mode before applying them.
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	checkDeletionProtection(ctx, req, resp)
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.warnUniqueNameChange(ctx, req, resp)
+	if resp.Diagnostics.HasError() || r.options == nil {
+		return
+	}
+
+	var id types.String
+	var options types.Dynamic
+	var typedOptions types.Object
+	var configuredMode, plannedMode types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("options"), &options)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("typed_options"), &typedOptions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("configuration_mode"), &configuredMode)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("configuration_mode"), &plannedMode)...)
+	if resp.Diagnostics.HasError() || id.ValueString() == "" {
+		return
 	}
 
-	service, err := r.client.Services.Create(ctx, createReq)
+	// Options set in both attributes were reported by ValidateConfig.
+	var ignored diag.Diagnostics
+	values, paths, ok := configuredOptions(options, typedOptions, &ignored)
+	if !ok {
+		return
+	}
+
+	catalog, err := r.options.Metadata(ctx, id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating CacheFly Service",
-			"Could not create service, unexpected error: "+err.Error(),
+		resp.Diagnostics.AddWarning(
+			"Could Not Validate CacheFly Service Options",
+			fmt.Sprintf("Could not fetch the options metadata of service ID %s, options will be validated by the API during apply: %s", id.ValueString(), err),
 		)
 		return
 	}
 
+	// Problems that ValidateConfig already reported as errors are skipped, so
+	// they do not show up twice. Unsupported fields were only warnings there,
+	// and the service's own metadata is authoritative for them.
+	// Problems are matched by option, field and kind, since the messages
+	// of the two catalogs can differ, for example in their suggestions.
+	reported := make(map[problemKey]bool)
+	builtinOpts := serviceoptions.ValidateOptions{ConfigurationMode: configuredMode.ValueString()}
+	for _, problem := range serviceoptions.Builtin().Validate(values, builtinOpts) {
+		if problem.Kind != serviceoptions.ProblemUnsupportedField {
+			reported[keyOf(problem)] = true
+		}
+	}
+
+	// Options are checked against the planned configuration mode, so
+	// options that a mode switch makes unavailable are reported here.
+	serviceOpts := serviceoptions.ValidateOptions{RejectUnknown: true, ConfigurationMode: plannedMode.ValueString()}
+	for _, problem := range catalog.Validate(values, serviceOpts) {
+		if reported[keyOf(problem)] {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			paths.problem(problem),
+			"Invalid Service Option",
+			problem.Message,
+		)
+	}
+}
+
+func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data models.ServiceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wantStatus := wantedStatus(data.Status)
+
+	var copiedOptions api.ServiceOptions
+	var copiedSettings sourceSettings
+
+	data.SourceBaseline = types.ObjectNull(models.ServiceSourceBaselineAttrTypes)
+	if data.SourceServiceID.ValueString() != "" {
+		copiedOptions, copiedSettings = r.copySource(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var service *api.Service
+	var err error
+
+	if data.AdoptExisting.ValueBool() {
+		service = r.adoptService(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	adopted := service != nil
+
+	if !adopted {
+		createReq := api.CreateServiceRequest{
+			Name:        data.Name.ValueString(),
+			UniqueName:  data.UniqueName.ValueString(),
+			Description: data.Description.ValueString(),
+		}
+
+		service, err = r.client.Services.Create(ctx, createReq)
+		if err != nil {
+			resp.Diagnostics.Append(apierrors.Diagnostic(
+				"Error Creating CacheFly Service",
+				"Could not create service, unexpected error",
+				err,
+			))
+			return
+		}
+	}
+
+	// From here on the service exists, so a failure saves it (and it is
+	// tainted) rather than leaving it orphaned with its unique name taken.
+	// Options are left out, since they were not applied.
+	saveCreated := func() {
+		r.mapServiceToState(service, &data)
+		data.Options = types.DynamicNull()
+		data.TypedOptions = types.ObjectNull(models.TypedOptionsAttrTypes())
+		data.UnmanagedOptions = types.DynamicNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+
 	var needsUpdate bool
 	var updateReq api.UpdateServiceRequest
 
+	// An adopted service keeps its old description unless it is updated.
+	if adopted {
+		needsUpdate = true
+		updateReq.Description = data.Description.ValueString()
+	}
+
 	if !data.AutoSSL.IsNull() && !data.AutoSSL.IsUnknown() {
 		needsUpdate = true
 		updateReq.AutoSSL = data.AutoSSL.ValueBool()
@@ -167,68 +495,147 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	if !data.TLSProfile.IsNull() {
 		needsUpdate = true
 		updateReq.TLSProfile = data.TLSProfile.ValueString()
+	} else if copiedSettings.TLSProfile != "" {
+		needsUpdate = true
+		updateReq.TLSProfile = copiedSettings.TLSProfile
 	}
 
 	if !data.DeliveryRegion.IsNull() {
 		needsUpdate = true
 		updateReq.DeliveryRegion = data.DeliveryRegion.ValueString()
+	} else if copiedSettings.DeliveryRegion != "" {
+		needsUpdate = true
+		updateReq.DeliveryRegion = copiedSettings.DeliveryRegion
 	}
 
 	if needsUpdate {
 		updatedService, err := r.client.Services.UpdateServiceByID(ctx, service.ID, updateReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Updating CacheFly Service Configuration",
-				"Service was created but configuration update failed: "+err.Error(),
-			)
+				"Service was created but configuration update failed",
+				err,
+			))
+			saveCreated()
 			return
 		}
 
 		service = updatedService
 	}
 
+	// The mode is switched before options are applied, since the options
+	// available depend on it.
+	if mode := plannedConfigurationMode(data.ConfigurationMode, service); mode != "" {
+		if !r.switchConfigurationMode(ctx, service, mode, &resp.Diagnostics) {
+			saveCreated()
+			return
+		}
+	}
+
 	if data.Status.ValueString() == "DEACTIVATED" {
-		service, err = r.client.Services.DeactivateServiceByID(ctx, service.ID)
+		deactivated, err := r.client.Services.DeactivateServiceByID(ctx, service.ID)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Deactivating CacheFly Service",
-				"Could not deactivate service: "+err.Error(),
-			)
+				"Could not deactivate service",
+				err,
+			))
+			saveCreated()
 			return
 		}
+		service = deactivated
 	}
 
-	if !data.Options.IsNull() && !data.Options.IsUnknown() {
+	// In exclusive mode, an adopted service must also lose the options it
+	// had before that are not in the configuration.
+	resetAdopted := adopted && data.ExclusiveOptions.ValueBool()
+
+	// appliedOptions are the option values the API accepted, which the wait
+	// for deployment looks for.
+	var appliedOptions api.ServiceOptions
+
+	if (!data.Options.IsNull() && !data.Options.IsUnknown()) || len(data.TypedOptionNames()) > 0 || resetAdopted || len(copiedOptions) > 0 {
 		serviceOptions, err := data.ToAPIServiceOptions()
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error converting service options",
 				"Could not convert service options: "+err.Error(),
 			)
+			saveCreated()
 			return
 		}
 
-		_, err = r.client.ServiceOptions.UpdateOptions(ctx, service.ID, serviceOptions)
+		// Options in the configuration override the copied ones.
+		for key, value := range copiedOptions {
+			if _, ok := serviceOptions[key]; !ok {
+				serviceOptions[key] = value
+			}
+		}
+
+		r.checkOptionsForMode(ctx, service, serviceOptions, data.TypedOptionNames(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			saveCreated()
+			return
+		}
+
+		if resetAdopted {
+			existing, err := r.client.ServiceOptions.GetOptions(ctx, service.ID)
+			if err != nil {
+				resp.Diagnostics.Append(apierrors.Diagnostic(
+					"Error Reading Service Options",
+					"Could not read the options of the adopted service",
+					err,
+				))
+				saveCreated()
+				return
+			}
+
+			unmanaged := r.unmanagedOptions(ctx, service.ID, serviceOptions, existing)
+			for key, value := range r.removedOptions(ctx, service.ID, data.OptionsRemovalBehavior.ValueString(), unmanaged, serviceOptions, &resp.Diagnostics) {
+				serviceOptions[key] = value
+			}
+		}
+
+		appliedOptions, err = r.client.ServiceOptions.UpdateOptions(ctx, service.ID, serviceOptions)
 		if err != nil {
-			// Check if it's a validation error
-			if validationErr, ok := err.(api.ServiceOptionsValidationError); ok {
-				resp.Diagnostics.AddError(
-					"Service Options Validation Failed",
-					fmt.Sprintf("Validation failed: %s. Details: %v", validationErr.Message, validationErr.Errors),
-				)
+			if diags, ok := optionsValidationDiagnostics(err, serviceOptions, data.TypedOptionNames()); ok {
+				resp.Diagnostics.Append(diags...)
+				saveCreated()
 				return
 			}
 
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error updating service options",
-				"Could not update service options: "+err.Error(),
-			)
+				"Could not update service options",
+				err,
+			))
+			saveCreated()
 			return
 		}
 	}
 
 	r.mapServiceToState(service, &data)
 
+	if data.WaitForDeployment.ValueBool() {
+		r.waitForDeployment(ctx, &data, "create", wantStatus, appliedOptions, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// The service exists, so it is saved (and tainted) rather than
+			// orphaned.
+			data.UnmanagedOptions = types.DynamicNull()
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	if err := r.refreshUnmanagedOptions(ctx, &data); err != nil {
+		resp.Diagnostics.Append(apierrors.Diagnostic(
+			"Error Reading Service Options",
+			"Could not read service options",
+			err,
+		))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -243,29 +650,43 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	service, err := r.client.Services.GetByID(ctx, data.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Service",
-				"Could not read service ID "+data.ID.ValueString()+": "+err.Error(),
-			)
+				"Could not read service ID "+data.ID.ValueString(),
+				err,
+			))
 		}
 		return
 	}
 
+	imported, diags := req.Private.GetKey(ctx, importedPrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Handle service options based on whether they are configured or imported
-	if err := r.handleServiceOptionsRead(ctx, &data); err != nil {
-		resp.Diagnostics.AddError(
+	if err := r.handleServiceOptionsRead(ctx, &data, len(imported) > 0); err != nil {
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Reading Service Options",
-			"Could not read service options: "+err.Error(),
-		)
+			"Could not read service options",
+			err,
+		))
 		return
 	}
 
 	// Map fresh API data to state
 	r.mapServiceToState(service, &data)
 
+	r.warnSourceDrift(ctx, &data, &resp.Diagnostics)
+
+	if len(imported) > 0 {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateStateKey, nil)...)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -284,72 +705,127 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	// The source is only copied on create, so the baseline never changes.
+	if data.SourceBaseline.IsUnknown() {
+		data.SourceBaseline = state.SourceBaseline
+	}
+
+	wantStatus := wantedStatus(data.Status)
+
+	update := newServiceUpdate(data, state)
+	savePartialState := func(failed string) {
+		if partial, ok := update.failed(failed, &resp.Diagnostics); ok {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &partial)...)
+		}
+	}
+
 	updateReq := api.UpdateServiceRequest{}
+	settingsChanged := false
 
 	if !data.Description.Equal(state.Description) {
 		updateReq.Description = data.Description.ValueString()
+		settingsChanged = true
 	}
 
 	if !data.AutoSSL.Equal(state.AutoSSL) {
 		updateReq.AutoSSL = data.AutoSSL.ValueBool()
+		settingsChanged = true
 	}
 
 	if !data.TLSProfile.Equal(state.TLSProfile) {
 		updateReq.TLSProfile = data.TLSProfile.ValueString()
+		settingsChanged = true
 	}
 
 	if !data.DeliveryRegion.Equal(state.DeliveryRegion) {
 		updateReq.DeliveryRegion = data.DeliveryRegion.ValueString()
+		settingsChanged = true
 	}
 
-	service, err := r.client.Services.UpdateServiceByID(ctx, data.ID.ValueString(), updateReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating CacheFly Service",
-			"Could not update service, unexpected error: "+err.Error(),
-		)
-		return
+	var service *api.Service
+	var err error
+	if settingsChanged {
+		service, err = r.client.Services.UpdateServiceByID(ctx, data.ID.ValueString(), updateReq)
+		if err != nil {
+			resp.Diagnostics.Append(apierrors.Diagnostic(
+				"Error Updating CacheFly Service",
+				"Could not update service, unexpected error",
+				err,
+			))
+			return
+		}
+
+		update.done("update service settings")
+		update.partial.Description = data.Description
+		update.partial.TLSProfile = data.TLSProfile
+		update.partial.DeliveryRegion = data.DeliveryRegion
+	} else {
+		// Nothing to send, but the later steps need the current service.
+		service, err = r.client.Services.GetByID(ctx, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(apierrors.Diagnostic(
+				"Error Reading CacheFly Service",
+				"Could not read service "+data.ID.ValueString()+" to update it",
+				err,
+			))
+			return
+		}
+	}
+	r.mapServiceToState(service, &update.partial)
+
+	// The mode is switched before options are applied, since the options
+	// available depend on it.
+	if mode := plannedConfigurationMode(data.ConfigurationMode, service); mode != "" {
+		if !r.switchConfigurationMode(ctx, service, mode, &resp.Diagnostics) {
+			savePartialState("switch configuration mode")
+			return
+		}
+		update.done("switch configuration mode")
+		update.partial.ConfigurationMode = types.StringValue(mode)
 	}
 
 	if !data.Status.Equal(state.Status) {
 		if data.Status.ValueString() == "ACTIVE" {
 			service, err = r.client.Services.ActivateServiceByID(ctx, data.ID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
+				resp.Diagnostics.Append(apierrors.Diagnostic(
 					"Error Activating CacheFly Service",
-					"Could not activate service: "+err.Error(),
-				)
+					"Could not activate service",
+					err,
+				))
+				savePartialState("activate service")
 				return
 			}
+			update.done("activate service")
 		} else {
 			service, err = r.client.Services.DeactivateServiceByID(ctx, data.ID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
+				resp.Diagnostics.Append(apierrors.Diagnostic(
 					"Error Deactivating CacheFly Service",
-					"Could not deactivate service: "+err.Error(),
-				)
+					"Could not deactivate service",
+					err,
+				))
+				savePartialState("deactivate service")
 				return
 			}
+			update.done("deactivate service")
 		}
+		r.mapServiceToState(service, &update.partial)
 	}
 
 	r.mapServiceToState(service, &data)
 
-	if !data.Options.Equal(state.Options) {
-		// Get current state to compare with planned changes
-		var currentState models.ServiceResourceModel
-		resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	var appliedOptions api.ServiceOptions
 
+	if !data.Options.Equal(state.Options) || !data.TypedOptions.Equal(state.TypedOptions) {
 		// Convert both current and planned options to API format for comparison
-		currentOptions, err := currentState.ToAPIServiceOptions()
+		currentOptions, err := state.ToAPIServiceOptions()
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Converting Current Service Options",
 				"Could not convert current service options: "+err.Error(),
 			)
+			savePartialState("update service options")
 			return
 		}
 
@@ -359,6 +835,7 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 				"Error Converting Planned Service Options",
 				"Could not convert planned service options: "+err.Error(),
 			)
+			savePartialState("update service options")
 			return
 		}
 
@@ -375,16 +852,53 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 			}
 		}
 
+		// Options removed from the configuration are reset on CacheFly,
+		// otherwise they would stay in effect without being managed.
+		for key, value := range r.removedOptions(ctx, data.ID.ValueString(), data.OptionsRemovalBehavior.ValueString(), currentOptions, plannedOptions, &resp.Diagnostics) {
+			changedOptions[key] = value
+		}
+
 		if len(changedOptions) > 0 {
-			_, err = r.client.ServiceOptions.UpdateOptions(ctx, data.ID.ValueString(), changedOptions)
+			appliedOptions, err = r.client.ServiceOptions.UpdateOptions(ctx, data.ID.ValueString(), changedOptions)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				if diags, ok := optionsValidationDiagnostics(err, changedOptions, data.TypedOptionNames()); ok {
+					resp.Diagnostics.Append(diags...)
+					savePartialState("update service options")
+					return
+				}
+
+				resp.Diagnostics.Append(apierrors.Diagnostic(
 					"Error Updating CacheFly Service Options",
-					"Could not update service options: "+err.Error(),
-				)
+					"Could not update service options",
+					err,
+				))
+				savePartialState("update service options")
 				return
 			}
+			update.done("update service options")
 		}
+		update.partial.Options = data.Options
+		update.partial.TypedOptions = data.TypedOptions
+	}
+
+	if data.WaitForDeployment.ValueBool() {
+		r.waitForDeployment(ctx, &data, "update", wantStatus, appliedOptions, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// Every change was made, only the wait failed, so the update is
+			// not reported as partial.
+			saved := update.waitFailed(data)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &saved)...)
+			return
+		}
+	}
+
+	if err := r.refreshUnmanagedOptions(ctx, &data); err != nil {
+		resp.Diagnostics.Append(apierrors.Diagnostic(
+			"Error Reading Service Options",
+			"Could not read service options",
+			err,
+		))
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -398,55 +912,252 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"CacheFly Service Is Protected From Deletion",
+			fmt.Sprintf("Service ID %s has deletion_protection enabled. Set deletion_protection = false and apply before destroying or replacing it.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	switch data.DeletionPolicy.ValueString() {
+	case deletionPolicyAbandon:
+		tflog.Info(ctx, "Removing service from state without changing it", map[string]interface{}{
+			"service_id": data.ID.ValueString(),
+		})
+		return
+
+	case deletionPolicyDelete:
+		if r.deleteService(ctx, data.ID.ValueString(), &resp.Diagnostics) {
+			return
+		}
+	}
+
 	_, err := r.client.Services.DeactivateServiceByID(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deactivating CacheFly Service",
-			"Could not deactivate service before deletion: "+err.Error(),
-		)
+			"Could not deactivate service before deletion",
+			err,
+		))
 		return
 	}
 
 	resp.Diagnostics.AddWarning(
 		"Resource deactivated, not deleted",
-		"The backing service was deactivated because hard delete is not supported by the API.",
+		"The backing service was deactivated because deletion_policy is \"deactivate\" or hard delete is not supported by the API.",
 	)
 }
 
+// adoptService looks up an existing service with the planned unique name and
+// reactivates it unless the plan deactivates it. It returns nil if there is
+// no such service. Only deactivated services are adopted: an active one may
+// be managed by another configuration, so it is reported as already existing.
+func (r *ServiceResource) adoptService(ctx context.Context, data *models.ServiceResourceModel, diags *diag.Diagnostics) *api.Service {
+	uniqueName := data.UniqueName.ValueString()
+
+	service, err := lookup.ServiceByUniqueName(ctx, r.client, uniqueName)
+	if errors.Is(err, lookup.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		diags.Append(apierrors.Diagnostic(
+			"Error Looking Up CacheFly Service",
+			"Could not look up an existing service with unique name "+uniqueName,
+			err,
+		))
+		return nil
+	}
+
+	if service.Status != "DEACTIVATED" {
+		diags.AddAttributeError(
+			path.Root("unique_name"),
+			"CacheFly Service Already Exists",
+			fmt.Sprintf("Service ID %s already uses unique name %s and is %s. adopt_existing only takes over deactivated services, "+
+				"since an active service may be managed elsewhere. To manage it with this resource, import it instead.",
+				service.ID, uniqueName, service.Status),
+		)
+		return nil
+	}
+
+	// Name changes require replacement, so an adopted service must already
+	// have the configured name.
+	if service.Name != data.Name.ValueString() {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Cannot Adopt CacheFly Service",
+			fmt.Sprintf("The existing service with unique name %s is named %q, but the configuration sets %q. Changing name replaces the service, so set name to %q to adopt it.",
+				uniqueName, service.Name, data.Name.ValueString(), service.Name),
+		)
+		return nil
+	}
+
+	tflog.Info(ctx, "Adopting existing service", map[string]interface{}{
+		"service_id":  service.ID,
+		"unique_name": uniqueName,
+		"status":      service.Status,
+	})
+
+	if service.Status != "ACTIVE" && data.Status.ValueString() != "DEACTIVATED" {
+		service, err = r.client.Services.ActivateServiceByID(ctx, service.ID)
+		if err != nil {
+			diags.Append(apierrors.Diagnostic(
+				"Error Activating CacheFly Service",
+				"Could not reactivate the adopted service",
+				err,
+			))
+			return nil
+		}
+	}
+
+	return service
+}
+
+// checkDeletionProtection rejects a plan that destroys or replaces a service
+// with deletion_protection enabled. Delete would refuse it as well, but only
+// during apply, after create_before_destroy has already created the
+// replacement.
+func checkDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var id types.String
+	var protected types.Bool
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if resp.Diagnostics.HasError() || !protected.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddError(
+			"CacheFly Service Is Protected From Deletion",
+			fmt.Sprintf("Service ID %s has deletion_protection enabled. Set deletion_protection = false and apply before destroying it.", id.ValueString()),
+		)
+		return
+	}
+
+	// These attributes require replacement, which deletes the current service.
+	for _, attribute := range []string{"name", "unique_name"} {
+		var stateValue, planValue types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &planValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !planValue.IsUnknown() && !planValue.Equal(stateValue) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"CacheFly Service Is Protected From Deletion",
+				fmt.Sprintf("Changing %s replaces service ID %s, which has deletion_protection enabled. Set deletion_protection = false and apply before replacing it, or revert %s.",
+					attribute, id.ValueString(), attribute),
+			)
+		}
+	}
+}
+
+// Values of the deletion_policy attribute.
+const (
+	deletionPolicyDeactivate = "deactivate"
+	deletionPolicyDelete     = "delete"
+	deletionPolicyAbandon    = "abandon"
+)
+
+// deleteService hard deletes the service. It returns false, without adding
+// diagnostics, if the API does not support deleting services, so the caller
+// can deactivate the service instead.
+func (r *ServiceResource) deleteService(ctx context.Context, serviceID string, diags *diag.Diagnostics) bool {
+	if r.api == nil {
+		return false
+	}
+
+	err := r.api.Delete(ctx, "/services/"+url.PathEscape(serviceID))
+	if err == nil {
+		return true
+	}
+
+	// A 404 can mean the service is already gone, or that the API has no
+	// DELETE route for services. Only the first is a successful delete.
+	if apierrors.IsNotFound(err) {
+		_, getErr := r.client.Services.GetByID(ctx, serviceID)
+		switch {
+		case apierrors.IsNotFound(getErr):
+			return true
+		case getErr == nil:
+			tflog.Warn(ctx, "The API does not delete services, deactivating the service instead", map[string]interface{}{
+				"service_id": serviceID,
+			})
+			return false
+		default:
+			diags.Append(apierrors.Diagnostic(
+				"Error Deleting CacheFly Service",
+				"The API could not find service ID "+serviceID+" to delete it, and checking whether it still exists failed",
+				getErr,
+			))
+			return true
+		}
+	}
+
+	if classified := apierrors.Classify(err); classified.StatusCode == http.StatusMethodNotAllowed || classified.StatusCode == http.StatusNotImplemented {
+		tflog.Warn(ctx, "Hard delete is not supported by the API, deactivating the service instead", map[string]interface{}{
+			"service_id": serviceID,
+		})
+		return false
+	}
+
+	diags.Append(apierrors.Diagnostic(
+		"Error Deleting CacheFly Service",
+		"Could not delete service ID "+serviceID,
+		err,
+	))
+	return true
+}
+
+// importedPrivateStateKey marks a service that was just imported, so the
+// following Read loads all of its options.
+const importedPrivateStateKey = "imported"
+
 func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateStateKey, []byte(`true`))...)
 }
 
-func (r *ServiceResource) handleServiceOptionsRead(ctx context.Context, data *models.ServiceResourceModel) error {
+func (r *ServiceResource) handleServiceOptionsRead(ctx context.Context, data *models.ServiceResourceModel, imported bool) error {
 	serviceID := data.ID.ValueString()
-	// if data.Options.IsNull() || data.Options.IsUnknown() {
-	// 	if r.isPostImportRead(data) {
-	// 		allOptions, err := r.client.ServiceOptions.GetOptions(ctx, serviceID)
-	// 		if err != nil {
-	// 			return fmt.Errorf("could not read service options for imported service: %w", err)
-	// 		}
-
-	// 		if err := r.setOptionsFromAPI(data, allOptions); err != nil {
-	// 			return fmt.Errorf("could not convert service options: %w", err)
-	// 		}
-
-	// 		tflog.Debug(ctx, "Loaded all service options for imported resource", map[string]interface{}{
-	// 			"service_id":    serviceID,
-
-	// 			"options_count": len(allOptions),
-	// 		})
-	// 	}
-	// 	return nil
-	// }
 
-	currentOptions, err := data.ToAPIServiceOptions()
+	allApiOptions, err := r.client.ServiceOptions.GetOptions(ctx, serviceID)
 	if err != nil {
-		return fmt.Errorf("could not convert current options: %w", err)
+		return fmt.Errorf("could not read current service options: %w", err)
 	}
 
-	allApiOptions, err := r.client.ServiceOptions.GetOptions(ctx, serviceID)
+	// An imported service has no options in state yet, so every option that
+	// differs from its default is loaded to make the service fully managed.
+	if imported {
+		catalog := r.optionsCatalog(ctx, serviceID)
+
+		importedOptions := make(api.ServiceOptions)
+		for key, value := range allApiOptions {
+			if !catalog.IsDefault(key, value) {
+				importedOptions[key] = value
+			}
+		}
+
+		if err := r.setOptionsFromAPI(data, importedOptions); err != nil {
+			return fmt.Errorf("could not convert service options for imported service: %w", err)
+		}
+		if err := r.setUnmanagedOptions(data, api.ServiceOptions{}); err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, "Loaded service options for imported resource", map[string]interface{}{
+			"service_id":    serviceID,
+			"options_count": len(importedOptions),
+		})
+		return nil
+	}
+
+	currentOptions, err := data.ToAPIServiceOptions()
 	if err != nil {
-		return fmt.Errorf("could not read current service options: %w", err)
+		return fmt.Errorf("could not convert current options: %w", err)
 	}
 
 	managedOptions := make(api.ServiceOptions)
@@ -457,48 +1168,138 @@ func (r *ServiceResource) handleServiceOptionsRead(ctx context.Context, data *mo
 		}
 	}
 
+	unmanaged := r.unmanagedOptions(ctx, serviceID, withBaselineOptions(ctx, data, currentOptions), allApiOptions)
+
+	// In exclusive mode unmanaged options are added to the state, so they
+	// show up as drift against the configuration.
+	if data.ExclusiveOptions.ValueBool() {
+		for key, value := range unmanaged {
+			managedOptions[key] = value
+		}
+	}
+
 	if err := r.setOptionsFromAPI(data, managedOptions); err != nil {
 		return fmt.Errorf("could not convert managed options: %w", err)
 	}
 
+	return r.setUnmanagedOptions(data, unmanaged)
+}
+
+// unmanagedOptions returns the options that differ from their default but are
+// not managed.
+func (r *ServiceResource) unmanagedOptions(ctx context.Context, serviceID string, managed, all api.ServiceOptions) api.ServiceOptions {
+	catalog := r.optionsCatalog(ctx, serviceID)
+
+	unmanaged := make(api.ServiceOptions)
+	for key, value := range all {
+		if _, ok := managed[key]; ok {
+			continue
+		}
+		if !catalog.IsDefault(key, value) {
+			unmanaged[key] = value
+		}
+	}
+	return unmanaged
+}
+
+// refreshUnmanagedOptions reads the options of the service after an apply
+// and records the ones that are not managed.
+func (r *ServiceResource) refreshUnmanagedOptions(ctx context.Context, data *models.ServiceResourceModel) error {
+	managed, err := data.ToAPIServiceOptions()
+	if err != nil {
+		return fmt.Errorf("could not convert managed options: %w", err)
+	}
+
+	all, err := r.client.ServiceOptions.GetOptions(ctx, data.ID.ValueString())
+	if err != nil {
+		return fmt.Errorf("could not read current service options: %w", err)
+	}
+
+	managed = withBaselineOptions(ctx, data, managed)
+
+	return r.setUnmanagedOptions(data, r.unmanagedOptions(ctx, data.ID.ValueString(), managed, all))
+}
+
+func (r *ServiceResource) setUnmanagedOptions(data *models.ServiceResourceModel, options api.ServiceOptions) error {
+	value, err := optionsToDynamic(options)
+	if err != nil {
+		return fmt.Errorf("could not convert unmanaged options: %w", err)
+	}
+	data.UnmanagedOptions = value
 	return nil
 }
 
-// isPostImportRead detects if this is a read immediately after import
-// by checking if we have minimal state (indicating fresh import)
-func (r *ServiceResource) isPostImportRead(data *models.ServiceResourceModel) bool {
-	// After import, we typically only have ID set and other fields may be in default state
-	// We can check if computed fields like Status, CreatedAt are not set yet
-	return data.Status.IsNull() || data.CreatedAt.IsNull()
+// optionsCatalog returns the options metadata of the service, or the built-in
+// catalog if the metadata cannot be fetched.
+func (r *ServiceResource) optionsCatalog(ctx context.Context, serviceID string) serviceoptions.Catalog {
+	if r.options != nil {
+		catalog, err := r.options.Metadata(ctx, serviceID)
+		if err == nil {
+			return catalog
+		}
+		tflog.Debug(ctx, "Falling back to the built-in service options catalog", map[string]interface{}{
+			"service_id": serviceID,
+			"error":      err.Error(),
+		})
+	}
+	return serviceoptions.Builtin()
 }
 
-// setOptionsFromAPI converts API ServiceOptions directly to the ServiceModel's Options field
+// setOptionsFromAPI converts API ServiceOptions directly to the ServiceModel's Options field.
+// Options set in typed_options are written back there instead.
 func (r *ServiceResource) setOptionsFromAPI(data *models.ServiceResourceModel, options api.ServiceOptions) error {
-	// Convert map[string]interface{} to types.Dynamic
-	if len(options) > 0 {
-		// Create a map[string]attr.Value for the dynamic type
-		elements := make(map[string]attr.Value)
-		attrTypes := make(map[string]attr.Type)
-
+	if typedNames := data.TypedOptionNames(); len(typedNames) > 0 {
+		typed := make(map[string]interface{}, len(typedNames))
+		untyped := make(api.ServiceOptions, len(options))
 		for key, value := range options {
-			// Convert interface{} to appropriate types.Value based on type
-			convertedValue, attrType := convertInterfaceToAttrValue(value)
-			elements[key] = convertedValue
-			attrTypes[key] = attrType
+			if typedNames[key] {
+				typed[key] = value
+			} else {
+				untyped[key] = value
+			}
 		}
 
-		// Create the object value
-		objValue, diags := types.ObjectValue(attrTypes, elements)
-		if diags.HasError() {
-			return fmt.Errorf("failed to convert options to object: %v", diags.Errors())
+		value, err := serviceoptions.Builtin().ObjectValue(typed)
+		if err != nil {
+			return fmt.Errorf("could not convert typed options: %w", err)
 		}
+		data.TypedOptions = value
+		options = untyped
+	}
 
-		data.Options = types.DynamicValue(objValue)
+	if len(options) > 0 {
+		value, err := optionsToDynamic(options)
+		if err != nil {
+			return err
+		}
+		data.Options = value
 	}
 
 	return nil
 }
 
+// optionsToDynamic converts API ServiceOptions to a dynamic object value.
+func optionsToDynamic(options api.ServiceOptions) (types.Dynamic, error) {
+	// Create a map[string]attr.Value for the dynamic type
+	elements := make(map[string]attr.Value)
+	attrTypes := make(map[string]attr.Type)
+
+	for key, value := range options {
+		// Convert interface{} to appropriate types.Value based on type
+		convertedValue, attrType := convertInterfaceToAttrValue(value)
+		elements[key] = convertedValue
+		attrTypes[key] = attrType
+	}
+
+	// Create the object value
+	objValue, diags := types.ObjectValue(attrTypes, elements)
+	if diags.HasError() {
+		return types.DynamicNull(), fmt.Errorf("failed to convert options to object: %v", diags.Errors())
+	}
+
+	return types.DynamicValue(objValue), nil
+}
+
 // filterNestedOptions recursively filters API options to only include fields that were configured
 func (r *ServiceResource) filterNestedOptions(current, api interface{}) interface{} {
 	currentMap, currentIsMap := current.(map[string]interface{})
@@ -627,14 +1428,246 @@ func (r *ServiceResource) mapServiceToState(service *api.Service, data *models.S
 	data.UpdatedAt = types.StringValue(service.UpdatedAt)
 
 	data.AutoSSL = types.BoolValue(service.AutoSSL)
-	data.ConfigurationMode = types.StringValue(service.ConfigurationMode)
 
-	if service.ConfigurationMode == "" && !data.ConfigurationMode.IsNull() {
-		// Keep the user's configuration if API doesn't return anything
-	} else {
-		// Use what the API returned
+	// Some responses leave out the configuration mode. The known value is
+	// kept then, so that it does not show up as a change on every plan.
+	switch {
+	case service.ConfigurationMode != "":
 		data.ConfigurationMode = types.StringValue(service.ConfigurationMode)
+	case data.ConfigurationMode.IsUnknown():
+		data.ConfigurationMode = types.StringNull()
 	}
 
 	// todo: (awet) TLSProfile and DeliveryRegion ,
 }
+
+// Values of the options_removal_behavior attribute.
+const (
+	optionsRemovalResetToDefault = "reset_to_default"
+	optionsRemovalDisable        = "disable"
+	optionsRemovalIgnore         = "ignore"
+)
+
+// removedOptions returns the values to send for options that are in the prior
+// state but not in the plan, according to the removal behavior. An empty
+// behavior means reset_to_default. Options whose default is unknown are left
+// as they are, with a warning.
+func (r *ServiceResource) removedOptions(ctx context.Context, serviceID, behavior string, current, planned api.ServiceOptions, diags *diag.Diagnostics) api.ServiceOptions {
+	removed := make(api.ServiceOptions)
+	if behavior == optionsRemovalIgnore {
+		return removed
+	}
+
+	gone := make(map[string]interface{})
+	for key, currentValue := range current {
+		if _, ok := planned[key]; !ok {
+			gone[key] = currentValue
+		}
+	}
+	if len(gone) == 0 {
+		return removed
+	}
+
+	if behavior == optionsRemovalDisable {
+		for key, currentValue := range gone {
+			if value, ok := serviceoptions.DisabledValue(currentValue); ok {
+				removed[key] = value
+			}
+		}
+		return removed
+	}
+
+	values, unknown := r.optionsCatalog(ctx, serviceID).ResetValues(gone)
+	for key, value := range values {
+		removed[key] = value
+	}
+
+	if len(unknown) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("options"),
+			"CacheFly Service Options Left Unchanged",
+			fmt.Sprintf("The defaults of these options removed from the configuration of service ID %s are not known, "+
+				"so they were left as they are on CacheFly: %s.\n\n"+
+				"Set options_removal_behavior to %q to switch them off instead, or set them to the wanted value in options.",
+				serviceID, strings.Join(unknown, ", "), optionsRemovalDisable),
+		)
+	}
+
+	return removed
+}
+
+// problemKey identifies a validation problem regardless of its message.
+type problemKey struct {
+	option string
+	field  string
+	kind   serviceoptions.ProblemKind
+}
+
+func keyOf(problem serviceoptions.Problem) problemKey {
+	return problemKey{option: problem.Option, field: problem.Field, kind: problem.Kind}
+}
+
+// optionPaths holds the options set in typed_options, so that diagnostics
+// point to the attribute an option is set in.
+type optionPaths map[string]bool
+
+// option returns the attribute path of an option.
+func (typed optionPaths) option(name string) path.Path {
+	if typed[name] {
+		return path.Root("typed_options").AtName(serviceoptions.AttributeName(name))
+	}
+	return path.Root("options").AtMapKey(name)
+}
+
+// problem returns the attribute path of the option or option field a
+// validation problem refers to.
+func (typed optionPaths) problem(problem serviceoptions.Problem) path.Path {
+	p := typed.option(problem.Option)
+	switch {
+	case problem.Field == "":
+		return p
+	case typed[problem.Option]:
+		return p.AtName(serviceoptions.AttributeName(problem.Field))
+	default:
+		return p.AtMapKey(problem.Field)
+	}
+}
+
+// configuredOptions returns the options set in options and typed_options, as
+// plain values for validation, and which of them are typed. An option set in
+// both attributes is reported. It returns false if there are no options to
+// validate, or if they are not known yet.
+func configuredOptions(options types.Dynamic, typedOptions types.Object, diags *diag.Diagnostics) (map[string]interface{}, optionPaths, bool) {
+	if options.IsUnknown() || typedOptions.IsUnknown() {
+		return nil, nil, false
+	}
+
+	values, ok := serviceoptions.MapFromValue(options)
+	if !ok {
+		if !options.IsNull() {
+			return nil, nil, false
+		}
+		values = make(map[string]interface{})
+	}
+
+	typed := serviceoptions.Builtin().FromObject(typedOptions)
+	paths := make(optionPaths, len(typed))
+	for key, value := range typed {
+		if _, ok := values[key]; ok {
+			diags.AddAttributeError(
+				path.Root("typed_options").AtName(serviceoptions.AttributeName(key)),
+				"Conflicting Service Option",
+				fmt.Sprintf("Option %s is set in both options and typed_options. Set it in only one of them.", key),
+			)
+			continue
+		}
+		values[key] = value
+		paths[key] = true
+	}
+
+	return values, paths, !options.IsNull() || len(paths) > 0
+}
+
+// optionsValidationDiagnostics turns an api.ServiceOptionsValidationError into
+// one attribute error per failed option, quoting the value that was sent. It
+// returns false if err is not a validation error.
+func optionsValidationDiagnostics(err error, sent api.ServiceOptions, paths optionPaths) (diag.Diagnostics, bool) {
+	// The SDK may return the error as a value or as a pointer.
+	var validationErr api.ServiceOptionsValidationError
+	var validationErrPtr *api.ServiceOptionsValidationError
+	switch {
+	case errors.As(err, &validationErr):
+	case errors.As(err, &validationErrPtr) && validationErrPtr != nil:
+		validationErr = *validationErrPtr
+	default:
+		return nil, false
+	}
+
+	var diags diag.Diagnostics
+
+	if len(validationErr.Errors) == 0 {
+		diags.AddError(
+			"Service Options Validation Failed",
+			"The CacheFly API rejected the service options: "+validationErr.Message,
+		)
+		return diags, true
+	}
+
+	for _, entry := range validationErr.Errors {
+		option, field, _ := strings.Cut(entry.Field, ".")
+
+		detail := entry.Message
+		if entry.Field != "" {
+			detail += "\n\nField: " + entry.Field
+		}
+		if value, ok := optionFieldValue(sent, option, field); ok {
+			detail += "\nValue: " + value
+		}
+
+		if _, ok := sent[option]; !ok {
+			diags.AddError("Service Options Validation Failed", detail)
+			continue
+		}
+
+		diags.AddAttributeError(
+			paths.option(option),
+			"Service Options Validation Failed",
+			detail,
+		)
+	}
+
+	return diags, true
+}
+
+// optionFieldValue formats the value sent for an option, or for one of its
+// nested fields, masking fields the options catalog marks as sensitive.
+func optionFieldValue(sent api.ServiceOptions, option, field string) (string, bool) {
+	value, ok := sent[option]
+	if !ok {
+		return "", false
+	}
+
+	for _, key := range strings.Split(field, ".") {
+		if key == "" {
+			break
+		}
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = nested[key]; !ok {
+			return "", false
+		}
+	}
+
+	if field != "" && isSensitiveOptionField(option, field) {
+		return "(sensitive value)", true
+	}
+
+	if fields, ok := value.(map[string]interface{}); ok && field == "" {
+		masked := make(map[string]interface{}, len(fields))
+		for key, fieldValue := range fields {
+			if isSensitiveOptionField(option, key) {
+				fieldValue = "(sensitive value)"
+			}
+			masked[key] = fieldValue
+		}
+		value = masked
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value), true
+	}
+	return string(encoded), true
+}
+
+func isSensitiveOptionField(option, field string) bool {
+	name, _, _ := strings.Cut(field, ".")
+	for _, f := range serviceoptions.Builtin()[option].Fields {
+		if f.Name == name {
+			return f.Sensitive
+		}
+	}
+	return false
+}
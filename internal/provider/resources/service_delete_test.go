@@ -0,0 +1,265 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+)
+
+// fakeServiceAPI answers the service requests made by Update and Delete and
+// records them as "METHOD path".
+type fakeServiceAPI struct {
+	deleteStatus int
+	getStatus    int
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeServiceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(f.deleteStatus)
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/options"):
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodGet:
+		w.WriteHeader(f.getStatus)
+		if f.getStatus == http.StatusOK {
+			_, _ = w.Write([]byte(`{"_id": "svc-1", "uniqueName": "example", "status": "ACTIVE"}`))
+		}
+	case r.Method == http.MethodPut:
+		_, _ = w.Write([]byte(`{"_id": "svc-1", "uniqueName": "example", "status": "DEACTIVATED"}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeServiceAPI) resource(t *testing.T) *ServiceResource {
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	return &ServiceResource{
+		client: cachefly.NewClient(
+			cachefly.WithToken("token"),
+			cachefly.WithBaseURL(server.URL),
+			cachefly.WithHTTPClient(server.Client()),
+		),
+		api: apiclient.New(server.Client(), server.URL, "token"),
+	}
+}
+
+func testServiceSchemaState(t *testing.T, data models.ServiceResourceModel) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewServiceResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("could not build state: %v", diags)
+	}
+	return state
+}
+
+func testServiceModel(policy string, protected bool) models.ServiceResourceModel {
+	return models.ServiceResourceModel{
+		ID:                 types.StringValue("svc-1"),
+		Name:               types.StringValue("example"),
+		UniqueName:         types.StringValue("example"),
+		DeletionPolicy:     types.StringValue(policy),
+		DeletionProtection: types.BoolValue(protected),
+		Options:            types.DynamicNull(),
+		TypedOptions:       types.ObjectNull(models.TypedOptionsAttrTypes()),
+		UnmanagedOptions:   types.DynamicNull(),
+		SourceBaseline:     types.ObjectNull(models.ServiceSourceBaselineAttrTypes),
+	}
+}
+
+func testServiceDelete(t *testing.T, fake *fakeServiceAPI, data models.ServiceResourceModel) diag.Diagnostics {
+	req := fwresource.DeleteRequest{State: testServiceSchemaState(t, data)}
+	resp := fwresource.DeleteResponse{State: req.State}
+
+	fake.resource(t).Delete(context.Background(), req, &resp)
+	return resp.Diagnostics
+}
+
+func TestServiceDelete(t *testing.T) {
+	tests := map[string]struct {
+		policy       string
+		protected    bool
+		deleteStatus int
+		getStatus    int
+
+		wantRequests []string
+		wantError    string
+		wantWarning  string
+	}{
+		"delete": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusNoContent,
+			wantRequests: []string{"DELETE /services/svc-1"},
+		},
+		"delete already gone": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusNotFound,
+			getStatus:    http.StatusNotFound,
+			wantRequests: []string{"DELETE /services/svc-1", "GET /services/svc-1"},
+		},
+		"delete route missing": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusNotFound,
+			getStatus:    http.StatusOK,
+			wantRequests: []string{"DELETE /services/svc-1", "GET /services/svc-1", "PUT /services/svc-1/deactivate"},
+			wantWarning:  "Resource deactivated, not deleted",
+		},
+		"delete existence check fails": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusNotFound,
+			getStatus:    http.StatusBadRequest,
+			wantRequests: []string{"DELETE /services/svc-1", "GET /services/svc-1"},
+			wantError:    "Error Deleting CacheFly Service",
+		},
+		"delete not allowed": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusMethodNotAllowed,
+			wantRequests: []string{"DELETE /services/svc-1", "PUT /services/svc-1/deactivate"},
+			wantWarning:  "Resource deactivated, not deleted",
+		},
+		"delete not implemented": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusNotImplemented,
+			wantRequests: []string{"DELETE /services/svc-1", "PUT /services/svc-1/deactivate"},
+			wantWarning:  "Resource deactivated, not deleted",
+		},
+		"delete fails": {
+			policy:       deletionPolicyDelete,
+			deleteStatus: http.StatusBadRequest,
+			wantRequests: []string{"DELETE /services/svc-1"},
+			wantError:    "Error Deleting CacheFly Service",
+		},
+		"deactivate": {
+			policy:       deletionPolicyDeactivate,
+			wantRequests: []string{"PUT /services/svc-1/deactivate"},
+			wantWarning:  "Resource deactivated, not deleted",
+		},
+		"abandon": {
+			policy: deletionPolicyAbandon,
+		},
+		"protected": {
+			policy:    deletionPolicyDelete,
+			protected: true,
+			wantError: "CacheFly Service Is Protected From Deletion",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeServiceAPI{deleteStatus: test.deleteStatus, getStatus: test.getStatus}
+
+			diags := testServiceDelete(t, fake, testServiceModel(test.policy, test.protected))
+
+			assert.Equal(t, test.wantRequests, fake.requests)
+
+			var errorSummaries, warningSummaries []string
+			for _, d := range diags {
+				if d.Severity() == diag.SeverityError {
+					errorSummaries = append(errorSummaries, d.Summary())
+				} else {
+					warningSummaries = append(warningSummaries, d.Summary())
+				}
+			}
+			if test.wantError == "" {
+				assert.Empty(t, errorSummaries)
+			} else {
+				assert.Equal(t, []string{test.wantError}, errorSummaries)
+			}
+			if test.wantWarning == "" {
+				assert.Empty(t, warningSummaries)
+			} else {
+				assert.Equal(t, []string{test.wantWarning}, warningSummaries)
+			}
+		})
+	}
+}
+
+func TestServiceModifyPlan_DeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	state := testServiceSchemaState(t, testServiceModel(deletionPolicyDeactivate, true))
+
+	renamed := testServiceModel(deletionPolicyDeactivate, true)
+	renamed.UniqueName = types.StringValue("renamed")
+	renamedState := testServiceSchemaState(t, renamed)
+
+	unprotected := testServiceSchemaState(t, testServiceModel(deletionPolicyDeactivate, false))
+
+	tests := map[string]struct {
+		state     tfsdk.State
+		plan      tfsdk.Plan
+		wantError bool
+		wantPath  path.Path
+	}{
+		"destroy": {
+			state:     state,
+			plan:      tfsdk.Plan{Schema: state.Schema, Raw: tftypes.NewValue(state.Raw.Type(), nil)},
+			wantError: true,
+			wantPath:  path.Empty(),
+		},
+		"replace": {
+			state:     state,
+			plan:      tfsdk.Plan(renamedState),
+			wantError: true,
+			wantPath:  path.Root("unique_name"),
+		},
+		"update": {
+			state: state,
+			plan:  tfsdk.Plan(state),
+		},
+		"destroy unprotected": {
+			state: unprotected,
+			plan:  tfsdk.Plan{Schema: state.Schema, Raw: tftypes.NewValue(state.Raw.Type(), nil)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ModifyPlanRequest{State: test.state, Plan: test.plan, Config: tfsdk.Config(test.plan)}
+			resp := fwresource.ModifyPlanResponse{Plan: test.plan}
+
+			(&ServiceResource{}).ModifyPlan(ctx, req, &resp)
+
+			if !test.wantError {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			errs := resp.Diagnostics.Errors()
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, "CacheFly Service Is Protected From Deletion", errs[0].Summary())
+				assert.Equal(t, test.wantPath, diagnosticPath(errs[0]))
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // satisfy framework interfaces.
@@ -102,16 +104,16 @@ func (r *ServiceDomainResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *ServiceDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -135,10 +137,11 @@ func (r *ServiceDomainResource) Create(ctx context.Context, req resource.CreateR
 
 	domain, err := r.client.ServiceDomains.Create(ctx, data.ServiceID.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly Service Domain",
-			"Could not create service domain, unexpected error: "+err.Error(),
-		)
+			"Could not create service domain, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -158,13 +161,14 @@ func (r *ServiceDomainResource) Read(ctx context.Context, req resource.ReadReque
 
 	domain, err := r.client.ServiceDomains.GetByID(ctx, data.ServiceID.ValueString(), data.ID.ValueString(), "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly Service Domain",
-				"Could not read service domain ID "+data.ID.ValueString()+": "+err.Error(),
-			)
+				"Could not read service domain ID "+data.ID.ValueString(),
+				err,
+			))
 		}
 		return
 	}
@@ -203,10 +207,11 @@ func (r *ServiceDomainResource) Update(ctx context.Context, req resource.UpdateR
 
 	domain, err := r.client.ServiceDomains.UpdateByID(ctx, data.ServiceID.ValueString(), data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Updating CacheFly Service Domain",
-			"Could not update service domain, unexpected error: "+err.Error(),
-		)
+			"Could not update service domain, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -225,10 +230,11 @@ func (r *ServiceDomainResource) Delete(ctx context.Context, req resource.DeleteR
 
 	err := r.client.ServiceDomains.DeleteByID(ctx, data.ServiceID.ValueString(), data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deleting CacheFly Service Domain",
-			"Could not delete service domain, unexpected error: "+err.Error(),
-		)
+			"Could not delete service domain, unexpected error",
+			err,
+		))
 		return
 	}
 }
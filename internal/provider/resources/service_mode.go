@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+// Values of the configuration_mode attribute.
+const (
+	configurationModeAPIRulesAndOptions   = "API_RULES_AND_OPTIONS"
+	configurationModeMixedRulesAndOptions = "MIXED_RULES_AND_OPTIONS"
+)
+
+// validateConfigurationMode reports a configuration_mode that is not one of
+// the known modes.
+func validateConfigurationMode(mode types.String, diags *diag.Diagnostics) {
+	if mode.IsNull() || mode.IsUnknown() {
+		return
+	}
+
+	switch mode.ValueString() {
+	case configurationModeAPIRulesAndOptions, configurationModeMixedRulesAndOptions:
+	default:
+		diags.AddAttributeError(
+			path.Root("configuration_mode"),
+			"Invalid Configuration Mode",
+			fmt.Sprintf("Expected %q or %q, got %q.",
+				configurationModeAPIRulesAndOptions, configurationModeMixedRulesAndOptions, mode.ValueString()),
+		)
+	}
+}
+
+// plannedConfigurationMode returns the configuration mode to switch the
+// service to, or an empty string if the plan leaves it as it is.
+func plannedConfigurationMode(planned types.String, service *api.Service) string {
+	if planned.IsNull() || planned.IsUnknown() || planned.ValueString() == service.ConfigurationMode {
+		return ""
+	}
+	return planned.ValueString()
+}
+
+// switchConfigurationMode moves the service to mode. The SDK cannot update
+// the configuration mode, so the request is sent directly. On success the
+// mode of service is updated to match.
+func (r *ServiceResource) switchConfigurationMode(ctx context.Context, service *api.Service, mode string, diags *diag.Diagnostics) bool {
+	if r.api == nil {
+		diags.AddError(
+			"Error Switching CacheFly Service Configuration Mode",
+			"No API client is configured. Please report this issue to the provider developers.",
+		)
+		return false
+	}
+
+	tflog.Info(ctx, "Switching service configuration mode", map[string]interface{}{
+		"service_id": service.ID,
+		"from":       service.ConfigurationMode,
+		"to":         mode,
+	})
+
+	request := map[string]string{"configurationMode": mode}
+	if err := r.api.Put(ctx, "/services/"+url.PathEscape(service.ID), request, nil); err != nil {
+		diags.Append(apierrors.Diagnostic(
+			"Error Switching CacheFly Service Configuration Mode",
+			fmt.Sprintf("Could not switch service ID %s to configuration mode %s", service.ID, mode),
+			err,
+		))
+		return false
+	}
+
+	service.ConfigurationMode = mode
+	return true
+}
+
+// checkOptionsForMode reports the options that are not available in the
+// configuration mode of a new service. The built-in catalog does not know
+// which modes an option is available in, and a service has no options
+// metadata before it exists, so the check runs once the service is created
+// and its mode switched, before the options are applied. If the metadata
+// cannot be read, the options are left for the API to validate.
+func (r *ServiceResource) checkOptionsForMode(ctx context.Context, service *api.Service, options api.ServiceOptions, paths optionPaths, diags *diag.Diagnostics) {
+	if r.options == nil || service.ConfigurationMode == "" || len(options) == 0 {
+		return
+	}
+
+	catalog, err := r.options.Metadata(ctx, service.ID)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read options metadata to check the configuration mode", map[string]interface{}{
+			"service_id": service.ID,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	for _, problem := range catalog.Validate(options, serviceoptions.ValidateOptions{ConfigurationMode: service.ConfigurationMode}) {
+		if problem.Kind != serviceoptions.ProblemConfigurationMode {
+			continue
+		}
+		diags.AddAttributeError(
+			paths.problem(problem),
+			"Invalid Service Option",
+			problem.Message,
+		)
+	}
+}
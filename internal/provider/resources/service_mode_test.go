@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestCheckOptionsForMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/svc-1/options/metadata", r.URL.Path)
+		_, _ = w.Write([]byte(`{"options": [
+			{"name": "cors", "type": "boolean"},
+			{"name": "legacyRules", "type": "boolean", "configurationModes": ["MIXED_RULES_AND_OPTIONS"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	r := &ServiceResource{options: serviceoptions.NewClient(server.Client(), server.URL, "token")}
+	options := api.ServiceOptions{"cors": true, "legacyRules": true, "unknownOption": true}
+
+	var diags diag.Diagnostics
+	r.checkOptionsForMode(context.Background(), &api.Service{ID: "svc-1", ConfigurationMode: configurationModeAPIRulesAndOptions}, options, nil, &diags)
+
+	// Only the mode is checked; other problems are left to the API.
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Invalid Service Option", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "legacyRules is not available in configuration mode API_RULES_AND_OPTIONS")
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		if assert.True(t, ok) {
+			assert.Equal(t, path.Root("options").AtMapKey("legacyRules"), withPath.Path())
+		}
+	}
+
+	diags = nil
+	r.checkOptionsForMode(context.Background(), &api.Service{ID: "svc-1", ConfigurationMode: configurationModeMixedRulesAndOptions}, options, nil, &diags)
+	assert.Empty(t, diags)
+}
+
+func TestMapServiceToState_ConfigurationMode(t *testing.T) {
+	r := &ServiceResource{}
+
+	data := models.ServiceResourceModel{ConfigurationMode: types.StringValue(configurationModeMixedRulesAndOptions)}
+	r.mapServiceToState(&api.Service{ID: "svc-1"}, &data)
+	assert.Equal(t, configurationModeMixedRulesAndOptions, data.ConfigurationMode.ValueString(), "a missing mode keeps the known value")
+
+	r.mapServiceToState(&api.Service{ID: "svc-1", ConfigurationMode: configurationModeAPIRulesAndOptions}, &data)
+	assert.Equal(t, configurationModeAPIRulesAndOptions, data.ConfigurationMode.ValueString())
+
+	data = models.ServiceResourceModel{ConfigurationMode: types.StringUnknown()}
+	r.mapServiceToState(&api.Service{ID: "svc-1"}, &data)
+	assert.True(t, data.ConfigurationMode.IsNull(), "an unknown mode is not left unknown after apply")
+}
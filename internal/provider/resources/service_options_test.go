@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func diagnosticPath(d diag.Diagnostic) path.Path {
+	if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+		return withPath.Path()
+	}
+	return path.Empty()
+}
+
+func TestOptionsValidationDiagnostics(t *testing.T) {
+	sent := api.ServiceOptions{
+		"autoRedirect": true,
+		"reverseProxy": map[string]interface{}{
+			"enabled":   true,
+			"hostname":  "bad host",
+			"secretKey": "secret",
+		},
+	}
+	validationErr := api.ServiceOptionsValidationError{
+		Message: "Validation failed",
+		Errors: []api.OptionValidationError{
+			{Field: "reverseProxy.hostname", Message: "hostname is invalid"},
+			{Field: "reverseProxy.secretKey", Message: "secretKey is invalid"},
+			{Field: "autoRedirect", Message: "autoRedirect is not allowed"},
+			{Field: "missingOption", Message: "missingOption is required"},
+		},
+	}
+
+	tests := map[string]error{
+		"value":   validationErr,
+		"pointer": &validationErr,
+		"wrapped": fmt.Errorf("update options: %w", &validationErr),
+	}
+
+	for name, err := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags, ok := optionsValidationDiagnostics(err, sent, nil)
+			if !assert.True(t, ok) || !assert.Len(t, diags, 4) {
+				return
+			}
+
+			assert.Equal(t, path.Root("options").AtMapKey("reverseProxy"), diagnosticPath(diags[0]))
+			assert.Contains(t, diags[0].Detail(), "hostname is invalid\n\nField: reverseProxy.hostname\nValue: \"bad host\"")
+
+			assert.Equal(t, path.Root("options").AtMapKey("reverseProxy"), diagnosticPath(diags[1]))
+			assert.Contains(t, diags[1].Detail(), "Value: (sensitive value)")
+			assert.NotContains(t, diags[1].Detail(), "secret\"")
+
+			assert.Equal(t, path.Root("options").AtMapKey("autoRedirect"), diagnosticPath(diags[2]))
+			assert.Contains(t, diags[2].Detail(), "Value: true")
+
+			// Options that were not sent have no attribute to point at.
+			assert.Equal(t, path.Empty(), diagnosticPath(diags[3]))
+			assert.Contains(t, diags[3].Detail(), "missingOption is required")
+		})
+	}
+}
+
+func TestOptionsValidationDiagnostics_WithoutFieldErrors(t *testing.T) {
+	diags, ok := optionsValidationDiagnostics(&api.ServiceOptionsValidationError{Message: "Invalid options"}, api.ServiceOptions{}, nil)
+
+	assert.True(t, ok)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "The CacheFly API rejected the service options: Invalid options", diags[0].Detail())
+	}
+}
+
+func TestOptionsValidationDiagnostics_OtherError(t *testing.T) {
+	_, ok := optionsValidationDiagnostics(errors.New("API error 500: boom"), api.ServiceOptions{}, nil)
+	assert.False(t, ok)
+
+	var nilErr *api.ServiceOptionsValidationError
+	_, ok = optionsValidationDiagnostics(fmt.Errorf("wrapped: %w", nilErr), api.ServiceOptions{}, nil)
+	assert.False(t, ok)
+}
+
+func TestOptionsValidationDiagnostics_TypedOptions(t *testing.T) {
+	sent := api.ServiceOptions{
+		"reverseProxy": map[string]interface{}{"enabled": true, "hostname": "bad host"},
+	}
+	validationErr := &api.ServiceOptionsValidationError{
+		Errors: []api.OptionValidationError{{Field: "reverseProxy.hostname", Message: "hostname is invalid"}},
+	}
+
+	diags, ok := optionsValidationDiagnostics(validationErr, sent, optionPaths{"reverseProxy": true})
+	assert.True(t, ok)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, path.Root("typed_options").AtName("reverse_proxy"), diagnosticPath(diags[0]))
+	}
+}
+
+func TestServiceResourceSchema(t *testing.T) {
+	var resp fwresource.SchemaResponse
+	(&ServiceResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, &resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.False(t, resp.Schema.ValidateImplementation(context.Background()).HasError())
+}
+
+func TestConfiguredOptions(t *testing.T) {
+	options, err := optionsToDynamic(api.ServiceOptions{"autoRedirect": true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	typedOptions, err := serviceoptions.Builtin().ObjectValue(map[string]interface{}{
+		"reverseProxy": map[string]interface{}{"enabled": true, "originScheme": "HTTPS"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var diags diag.Diagnostics
+	values, paths, ok := configuredOptions(options, typedOptions, &diags)
+	assert.True(t, ok)
+	assert.Empty(t, diags)
+	assert.Equal(t, map[string]interface{}{
+		"autoRedirect": true,
+		"reverseProxy": map[string]interface{}{"enabled": true, "originScheme": "HTTPS"},
+	}, values)
+
+	assert.Equal(t, path.Root("options").AtMapKey("autoRedirect"), paths.problem(serviceoptions.Problem{Option: "autoRedirect"}))
+	assert.Equal(t, path.Root("typed_options").AtName("reverse_proxy").AtName("origin_scheme"),
+		paths.problem(serviceoptions.Problem{Option: "reverseProxy", Field: "originScheme"}))
+
+	t.Run("conflict", func(t *testing.T) {
+		options, err := optionsToDynamic(api.ServiceOptions{"reverseProxy": map[string]interface{}{"enabled": false}})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var diags diag.Diagnostics
+		_, _, _ = configuredOptions(options, typedOptions, &diags)
+		if assert.Len(t, diags, 1) {
+			assert.Equal(t, "Conflicting Service Option", diags[0].Summary())
+			assert.Equal(t, path.Root("typed_options").AtName("reverse_proxy"), diagnosticPath(diags[0]))
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		var diags diag.Diagnostics
+		_, _, ok := configuredOptions(types.DynamicNull(), types.ObjectNull(models.TypedOptionsAttrTypes()), &diags)
+		assert.False(t, ok)
+	})
+}
+
+func TestSetOptionsFromAPI_TypedOptions(t *testing.T) {
+	typedOptions, err := serviceoptions.Builtin().ObjectValue(map[string]interface{}{"cors": true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	data := models.ServiceResourceModel{Options: types.DynamicNull(), TypedOptions: typedOptions}
+
+	err = (&ServiceResource{}).setOptionsFromAPI(&data, api.ServiceOptions{
+		"cors":         false,
+		"autoRedirect": true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Options set in typed_options are read back there, the others go to
+	// options.
+	assert.Equal(t, types.BoolValue(false), data.TypedOptions.Attributes()["cors"])
+	options, err := models.DynamicToAPIServiceOptions(data.Options)
+	assert.NoError(t, err)
+	assert.Equal(t, api.ServiceOptions{"autoRedirect": true}, options)
+}
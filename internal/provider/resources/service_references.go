@@ -0,0 +1,228 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+// referencePageSize is the number of objects fetched per list request when
+// looking for objects that reference a service.
+const referencePageSize = 100
+
+// referenceScanLimit is the most objects of each kind that are checked for
+// references to a service, so that a plan on a large account does not list
+// every script config and log target.
+const referenceScanLimit = 500
+
+// scriptConfigListResponse is the part of the script configs list response
+// needed to find the configs attached to a service. The SDK has no list
+// method for script configs.
+type scriptConfigListResponse struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Data []struct {
+		ID       string   `json:"_id"`
+		Name     string   `json:"name"`
+		Services []string `json:"services"`
+	} `json:"data"`
+}
+
+// serviceReferences lists the objects that point at a service and stop
+// working when it is replaced.
+type serviceReferences struct {
+	Domains       []string
+	ScriptConfigs []string
+	LogTargets    []string
+
+	// Failed names the lookups that could not be completed.
+	Failed []string
+
+	// Truncated names the lookups that stopped at referenceScanLimit.
+	Truncated []string
+}
+
+// warnUniqueNameChange explains what happens when unique_name changes. The
+// change replaces the service, and the domains, script configs and log
+// targets of the old service are not moved to the new one.
+func (r *ServiceResource) warnUniqueNameChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var id, stateName, planName, deletionPolicy types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("unique_name"), &stateName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("unique_name"), &planName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planName.IsUnknown() || planName.Equal(stateName) || id.ValueString() == "" {
+		return
+	}
+
+	var refs *serviceReferences
+	if r.client != nil {
+		found := r.serviceReferences(ctx, id.ValueString())
+		refs = &found
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("unique_name"),
+		"CacheFly Service Will Be Replaced",
+		uniqueNameChangeDetail(id.ValueString(), stateName.ValueString(), planName.ValueString(), deletionPolicy.ValueString(), refs),
+	)
+}
+
+// uniqueNameChangeDetail describes the replacement of service id caused by
+// changing its unique name from one value to another. refs may be nil if the
+// references could not be looked up.
+func uniqueNameChangeDetail(id, from, to, deletionPolicy string, refs *serviceReferences) string {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Changing unique_name from %q to %q replaces service ID %s. The service update request does not include the unique name, "+
+		"so a new service is created without the domains, script configs and log targets of the current one.",
+		from, to, id)
+
+	switch deletionPolicy {
+	case deletionPolicyAbandon:
+		detail.WriteString(" The current service is left as it is, because deletion_policy is \"abandon\".")
+	case deletionPolicyDelete:
+		detail.WriteString(" The current service is deleted, because deletion_policy is \"delete\".")
+	default:
+		detail.WriteString(" The current service is deactivated and stays in the account.")
+	}
+
+	if refs != nil {
+		writeReferences(&detail, "Domains", refs.Domains)
+		writeReferences(&detail, "Script configs", refs.ScriptConfigs)
+		writeReferences(&detail, "Log targets", refs.LogTargets)
+		if len(refs.Truncated) > 0 {
+			fmt.Fprintf(&detail, "\n\nOnly the first %d %s were checked for references to the service.",
+				referenceScanLimit, strings.Join(refs.Truncated, " and "))
+		}
+		if len(refs.Failed) > 0 {
+			fmt.Fprintf(&detail, "\n\nCould not check %s for references to the service.", strings.Join(refs.Failed, " or "))
+		}
+	}
+
+	detail.WriteString("\n\nTo keep the current service, revert unique_name.")
+	return detail.String()
+}
+
+func writeReferences(detail *strings.Builder, title string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(detail, "\n\n%s that reference the current service and would stop working:", title)
+	for _, name := range names {
+		detail.WriteString("\n  - " + name)
+	}
+}
+
+// serviceReferences looks up the domains, script configs and log targets of
+// a service, checking at most referenceScanLimit of each. Lookups that fail
+// are recorded in Failed rather than reported, since the result only adds
+// detail to a warning.
+func (r *ServiceResource) serviceReferences(ctx context.Context, serviceID string) serviceReferences {
+	var refs serviceReferences
+
+	domainOpts := api.ListServiceDomainsOptions{Limit: referencePageSize}
+	for {
+		listResp, err := r.client.ServiceDomains.List(ctx, serviceID, domainOpts)
+		if err != nil {
+			refs.Domains = nil
+			refs.Failed = append(refs.Failed, "domains")
+			break
+		}
+		for _, domain := range listResp.Domains {
+			refs.Domains = append(refs.Domains, domain.Name)
+		}
+		domainOpts.Offset += len(listResp.Domains)
+		if len(listResp.Domains) < referencePageSize || (listResp.Meta.Count > 0 && domainOpts.Offset >= listResp.Meta.Count) {
+			break
+		}
+		if domainOpts.Offset >= referenceScanLimit {
+			refs.Truncated = append(refs.Truncated, "domains")
+			break
+		}
+	}
+
+	if r.api == nil {
+		refs.Failed = append(refs.Failed, "script configs")
+	} else {
+		configs, complete, err := scriptConfigReferences(ctx, r.api, serviceID)
+		if err != nil {
+			refs.Failed = append(refs.Failed, "script configs")
+		} else {
+			refs.ScriptConfigs = configs
+			if !complete {
+				refs.Truncated = append(refs.Truncated, "script configs")
+			}
+		}
+	}
+
+	logTargetOpts := api.ListLogTargetsOptions{Limit: referencePageSize}
+	for {
+		listResp, err := r.client.LogTargets.List(ctx, logTargetOpts)
+		if err != nil {
+			refs.LogTargets = nil
+			refs.Failed = append(refs.Failed, "log targets")
+			break
+		}
+		for _, target := range listResp.LogTargets {
+			if target.AccessLogsServices != nil && slices.Contains(*target.AccessLogsServices, serviceID) ||
+				target.OriginLogsServices != nil && slices.Contains(*target.OriginLogsServices, serviceID) {
+				name := target.ID
+				if target.Name != nil && *target.Name != "" {
+					name = fmt.Sprintf("%s (%s)", *target.Name, target.ID)
+				}
+				refs.LogTargets = append(refs.LogTargets, name)
+			}
+		}
+		logTargetOpts.Offset += len(listResp.LogTargets)
+		if len(listResp.LogTargets) < referencePageSize || (listResp.Meta.Count > 0 && logTargetOpts.Offset >= listResp.Meta.Count) {
+			break
+		}
+		if logTargetOpts.Offset >= referenceScanLimit {
+			refs.Truncated = append(refs.Truncated, "log targets")
+			break
+		}
+	}
+
+	return refs
+}
+
+// scriptConfigReferences returns the script configs attached to a service.
+// The SDK has no list method for script configs, so they are read directly
+// from the API. It checks at most referenceScanLimit configs and reports
+// whether it checked them all.
+func scriptConfigReferences(ctx context.Context, client *apiclient.Client, serviceID string) ([]string, bool, error) {
+	var names []string
+	for offset := 0; ; {
+		var listResp scriptConfigListResponse
+		if err := client.Get(ctx, fmt.Sprintf("/scriptconfigs?offset=%d&limit=%d", offset, referencePageSize), &listResp); err != nil {
+			return nil, false, err
+		}
+		for _, config := range listResp.Data {
+			if slices.Contains(config.Services, serviceID) {
+				names = append(names, fmt.Sprintf("%s (%s)", config.Name, config.ID))
+			}
+		}
+		offset += len(listResp.Data)
+		if len(listResp.Data) < referencePageSize || (listResp.Meta.Count > 0 && offset >= listResp.Meta.Count) {
+			return names, true, nil
+		}
+		if offset >= referenceScanLimit {
+			return names, false, nil
+		}
+	}
+}
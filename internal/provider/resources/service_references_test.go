@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+// scriptConfigServer serves count script configs; every tenth one is
+// attached to svc-1. The list meta only has the count if withCount is set.
+func scriptConfigServer(t *testing.T, count int, withCount bool, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		data := []map[string]interface{}{}
+		for i := offset; i < offset+limit && i < count; i++ {
+			services := []string{"svc-2"}
+			if i%10 == 0 {
+				services = append(services, "svc-1")
+			}
+			data = append(data, map[string]interface{}{
+				"_id":      fmt.Sprintf("cfg-%d", i),
+				"name":     fmt.Sprintf("config %d", i),
+				"services": services,
+			})
+		}
+		meta := map[string]interface{}{}
+		if withCount {
+			meta["count"] = count
+		}
+		listResp := map[string]interface{}{"meta": meta, "data": data}
+		assert.NoError(t, json.NewEncoder(w).Encode(listResp))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestScriptConfigReferences(t *testing.T) {
+	var requests int
+	server := scriptConfigServer(t, 150, true, &requests)
+
+	names, complete, err := scriptConfigReferences(context.Background(), apiclient.New(server.Client(), server.URL, "token"), "svc-1")
+
+	assert.NoError(t, err)
+	assert.True(t, complete)
+	assert.Len(t, names, 15)
+	assert.Equal(t, "config 0 (cfg-0)", names[0])
+	assert.Equal(t, 2, requests)
+}
+
+func TestScriptConfigReferences_StopsAtScanLimit(t *testing.T) {
+	var requests int
+	server := scriptConfigServer(t, 10*referenceScanLimit, true, &requests)
+
+	names, complete, err := scriptConfigReferences(context.Background(), apiclient.New(server.Client(), server.URL, "token"), "svc-1")
+
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Len(t, names, referenceScanLimit/10)
+	assert.Equal(t, referenceScanLimit/referencePageSize, requests)
+}
+
+func TestScriptConfigReferences_WithoutCount(t *testing.T) {
+	var requests int
+	server := scriptConfigServer(t, 150, false, &requests)
+
+	names, complete, err := scriptConfigReferences(context.Background(), apiclient.New(server.Client(), server.URL, "token"), "svc-1")
+
+	// Without a count, paging stops at the first short page.
+	assert.NoError(t, err)
+	assert.True(t, complete)
+	assert.Len(t, names, 15)
+	assert.Equal(t, 2, requests)
+}
+
+func TestUniqueNameChangeDetail(t *testing.T) {
+	refs := &serviceReferences{
+		Domains:       []string{"cdn.example.com"},
+		ScriptConfigs: []string{"config 0 (cfg-0)"},
+		Failed:        []string{"log targets"},
+		Truncated:     []string{"script configs"},
+	}
+
+	detail := uniqueNameChangeDetail("svc-1", "old", "new", deletionPolicyDelete, refs)
+
+	assert.Contains(t, detail, `Changing unique_name from "old" to "new" replaces service ID svc-1.`)
+	assert.Contains(t, detail, `The current service is deleted, because deletion_policy is "delete".`)
+	assert.Contains(t, detail, "Domains that reference the current service and would stop working:\n  - cdn.example.com")
+	assert.Contains(t, detail, "Script configs that reference the current service and would stop working:\n  - config 0 (cfg-0)")
+	assert.Contains(t, detail, fmt.Sprintf("Only the first %d script configs were checked", referenceScanLimit))
+	assert.Contains(t, detail, "Could not check log targets for references to the service.")
+	assert.NotContains(t, detail, "Log targets that reference")
+
+	detail = uniqueNameChangeDetail("svc-1", "old", "new", "", nil)
+
+	assert.Contains(t, detail, "The current service is deactivated and stays in the account.")
+	assert.NotContains(t, detail, "reference the current service")
+}
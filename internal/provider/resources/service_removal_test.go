@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+// removalTestResource returns a resource whose service svc-1 knows the
+// defaults of cors and error_ttl, but not of ttfb_timeout.
+func removalTestResource(t *testing.T) *ServiceResource {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/svc-1/options/metadata", r.URL.Path)
+		_, _ = w.Write([]byte(`{"options": [
+			{"name": "cors", "type": "boolean", "default": false},
+			{"name": "error_ttl", "type": "standard", "value": {"type": "integer"}, "default": {"enabled": true, "value": 60}},
+			{"name": "ttfb_timeout", "type": "standard", "value": {"type": "integer"}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return &ServiceResource{options: serviceoptions.NewClient(server.Client(), server.URL, "token")}
+}
+
+func TestRemovedOptions(t *testing.T) {
+	current := api.ServiceOptions{
+		"autoRedirect": true,
+		"cors":         true,
+		"error_ttl":    map[string]interface{}{"enabled": true, "value": 5},
+	}
+	planned := api.ServiceOptions{"autoRedirect": true}
+
+	tests := map[string]struct {
+		behavior string
+		want     api.ServiceOptions
+	}{
+		"reset_to_default": {
+			behavior: optionsRemovalResetToDefault,
+			want: api.ServiceOptions{
+				"cors":      false,
+				"error_ttl": map[string]interface{}{"enabled": true, "value": float64(60)},
+			},
+		},
+		// An empty behavior is the default, reset_to_default.
+		"unset": {
+			behavior: "",
+			want: api.ServiceOptions{
+				"cors":      false,
+				"error_ttl": map[string]interface{}{"enabled": true, "value": float64(60)},
+			},
+		},
+		"disable": {
+			behavior: optionsRemovalDisable,
+			want: api.ServiceOptions{
+				"cors":      false,
+				"error_ttl": map[string]interface{}{"enabled": false},
+			},
+		},
+		"ignore": {
+			behavior: optionsRemovalIgnore,
+			want:     api.ServiceOptions{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			removed := removalTestResource(t).removedOptions(context.Background(), "svc-1", test.behavior, current, planned, &diags)
+
+			assert.Equal(t, test.want, removed)
+			assert.Empty(t, diags)
+		})
+	}
+}
+
+func TestRemovedOptions_UnknownDefault(t *testing.T) {
+	current := api.ServiceOptions{
+		"cors":         true,
+		"ttfb_timeout": map[string]interface{}{"enabled": true, "value": 5},
+	}
+
+	var diags diag.Diagnostics
+	removed := removalTestResource(t).removedOptions(context.Background(), "svc-1", optionsRemovalResetToDefault, current, api.ServiceOptions{}, &diags)
+
+	// The option without a known default is left as it is.
+	assert.Equal(t, api.ServiceOptions{"cors": false}, removed)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+		assert.Equal(t, "CacheFly Service Options Left Unchanged", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "service ID svc-1")
+		assert.Contains(t, diags[0].Detail(), ": ttfb_timeout.")
+		assert.Equal(t, path.Root("options"), diagnosticPath(diags[0]))
+	}
+}
+
+func TestRemovedOptions_NothingRemoved(t *testing.T) {
+	current := api.ServiceOptions{"cors": true}
+
+	var diags diag.Diagnostics
+	removed := (&ServiceResource{}).removedOptions(context.Background(), "svc-1", optionsRemovalResetToDefault, current, current, &diags)
+
+	assert.Empty(t, removed)
+	assert.Empty(t, diags)
+}
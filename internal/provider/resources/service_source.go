@@ -0,0 +1,247 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+)
+
+// sourceSettings is the part of a service response that is copied from a
+// source service. The SDK service type has no TLS profile or delivery region.
+type sourceSettings struct {
+	TLSProfile     string
+	DeliveryRegion string
+}
+
+// UnmarshalJSON decodes the settings from a service response. The TLS
+// profile and delivery region are references, which the API returns as an ID
+// or, in deep responses, as the referenced object.
+func (s *sourceSettings) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		TLSProfile     json.RawMessage `json:"tlsProfile"`
+		DeliveryRegion json.RawMessage `json:"deliveryRegion"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if s.TLSProfile, err = apiclient.ReferenceID(raw.TLSProfile); err != nil {
+		return fmt.Errorf("tlsProfile: %w", err)
+	}
+	if s.DeliveryRegion, err = apiclient.ReferenceID(raw.DeliveryRegion); err != nil {
+		return fmt.Errorf("deliveryRegion: %w", err)
+	}
+	return nil
+}
+
+// readSourceSettings reads the settings of a service directly from the API.
+func readSourceSettings(ctx context.Context, client *apiclient.Client, serviceID string) (sourceSettings, error) {
+	var settings sourceSettings
+	if client == nil {
+		return settings, fmt.Errorf("no API client is configured")
+	}
+	err := client.Get(ctx, "/services/"+url.PathEscape(serviceID), &settings)
+	return settings, err
+}
+
+// readSource returns what a new service copies from the source service: the
+// options that differ from their default, the TLS profile and the delivery
+// region.
+func (r *ServiceResource) readSource(ctx context.Context, sourceID string) (api.ServiceOptions, sourceSettings, error) {
+	all, err := r.client.ServiceOptions.GetOptions(ctx, sourceID)
+	if err != nil {
+		return nil, sourceSettings{}, err
+	}
+
+	catalog := r.optionsCatalog(ctx, sourceID)
+	options := make(api.ServiceOptions)
+	for key, value := range all {
+		if !catalog.IsDefault(key, value) {
+			options[key] = value
+		}
+	}
+
+	settings, err := readSourceSettings(ctx, r.api, sourceID)
+	if err != nil {
+		return nil, settings, err
+	}
+
+	return options, settings, nil
+}
+
+// copySource reads the source service of a new service and records it as the
+// baseline in data. It returns the options to copy; options set in the
+// configuration take precedence over them.
+func (r *ServiceResource) copySource(ctx context.Context, data *models.ServiceResourceModel, diags *diag.Diagnostics) (api.ServiceOptions, sourceSettings) {
+	sourceID := data.SourceServiceID.ValueString()
+
+	options, settings, err := r.readSource(ctx, sourceID)
+	if err != nil {
+		diags.Append(apierrors.Diagnostic(
+			"Error Reading CacheFly Source Service",
+			"Could not read the options and settings of source service ID "+sourceID,
+			err,
+		))
+		return nil, settings
+	}
+
+	baseline, err := sourceBaselineValue(ctx, options, settings)
+	if err != nil {
+		diags.AddError(
+			"Error Recording CacheFly Source Service",
+			"Could not record the settings copied from source service ID "+sourceID+": "+err.Error(),
+		)
+		return nil, settings
+	}
+	data.SourceBaseline = baseline
+
+	tflog.Debug(ctx, "Copying settings from source service", map[string]interface{}{
+		"source_service_id": sourceID,
+		"options_count":     len(options),
+	})
+
+	return options, settings
+}
+
+func sourceBaselineValue(ctx context.Context, options api.ServiceOptions, settings sourceSettings) (types.Object, error) {
+	optionsValue, err := optionsToDynamic(options)
+	if err != nil {
+		return types.ObjectNull(models.ServiceSourceBaselineAttrTypes), err
+	}
+
+	baseline := models.ServiceSourceBaselineModel{
+		Options:        optionsValue,
+		TLSProfile:     types.StringValue(settings.TLSProfile),
+		DeliveryRegion: types.StringValue(settings.DeliveryRegion),
+	}
+
+	value, diags := types.ObjectValueFrom(ctx, models.ServiceSourceBaselineAttrTypes, baseline)
+	if diags.HasError() {
+		return types.ObjectNull(models.ServiceSourceBaselineAttrTypes), fmt.Errorf("%v", diags.Errors())
+	}
+	return value, nil
+}
+
+// baselineOptions returns the options recorded in source_baseline, or nil.
+func baselineOptions(ctx context.Context, data *models.ServiceResourceModel) (models.ServiceSourceBaselineModel, api.ServiceOptions, bool) {
+	var baseline models.ServiceSourceBaselineModel
+	if data.SourceBaseline.IsNull() || data.SourceBaseline.IsUnknown() {
+		return baseline, nil, false
+	}
+
+	if diags := data.SourceBaseline.As(ctx, &baseline, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return baseline, nil, false
+	}
+
+	options, err := models.DynamicToAPIServiceOptions(baseline.Options)
+	if err != nil {
+		return baseline, nil, false
+	}
+	return baseline, options, true
+}
+
+// withBaselineOptions adds the options copied from the source service to
+// managed. They are part of the service's configuration, so they are not
+// reported as unmanaged.
+func withBaselineOptions(ctx context.Context, data *models.ServiceResourceModel, managed api.ServiceOptions) api.ServiceOptions {
+	_, copied, ok := baselineOptions(ctx, data)
+	if !ok || len(copied) == 0 {
+		return managed
+	}
+
+	merged := make(api.ServiceOptions, len(managed)+len(copied))
+	for key, value := range copied {
+		merged[key] = value
+	}
+	for key, value := range managed {
+		merged[key] = value
+	}
+	return merged
+}
+
+// warnSourceDrift reports changes made to the source service since the
+// service was copied from it. The changes are not applied to the service.
+// It is called from Read, so the source is read once per refresh rather than
+// on every plan.
+func (r *ServiceResource) warnSourceDrift(ctx context.Context, data *models.ServiceResourceModel, diags *diag.Diagnostics) {
+	sourceID := data.SourceServiceID.ValueString()
+	if sourceID == "" || r.client == nil {
+		return
+	}
+
+	baseline, copied, ok := baselineOptions(ctx, data)
+	if !ok {
+		return
+	}
+
+	current, settings, err := r.readSource(ctx, sourceID)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read source service to check for changes", map[string]interface{}{
+			"source_service_id": sourceID,
+			"error":             err.Error(),
+		})
+		return
+	}
+
+	var changed []string
+	for _, key := range changedOptionKeys(copied, current) {
+		changed = append(changed, "options."+key)
+	}
+	if settings.TLSProfile != baseline.TLSProfile.ValueString() {
+		changed = append(changed, "tls_profile")
+	}
+	if settings.DeliveryRegion != baseline.DeliveryRegion.ValueString() {
+		changed = append(changed, "delivery_region")
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("source_service_id"),
+		"CacheFly Source Service Changed",
+		fmt.Sprintf("Source service ID %s has changed since service ID %s was copied from it: %s.\n\n"+
+			"These changes are not applied to this service. Set them in the configuration to apply them, or recreate the service to copy the source again.",
+			sourceID, data.ID.ValueString(), strings.Join(changed, ", ")),
+	)
+}
+
+// changedOptionKeys returns the keys whose values differ between before and
+// after, including keys present in only one of them.
+func changedOptionKeys(before, after api.ServiceOptions) []string {
+	var keys []string
+	for key, value := range before {
+		if other, ok := after[key]; !ok || !sameJSON(value, other) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sameJSON(a, b interface{}) bool {
+	left, errLeft := json.Marshal(a)
+	right, errRight := json.Marshal(b)
+	return errLeft == nil && errRight == nil && string(left) == string(right)
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+func TestReadSourceSettings(t *testing.T) {
+	tests := map[string]string{
+		"shallow": "service.json",
+		"deep":    "service_deep.json",
+	}
+
+	for name, fixture := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", fixture))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/services/5f8a1b2c3d4e5f6a7b8c9d0e", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			settings, err := readSourceSettings(context.Background(), apiclient.New(server.Client(), server.URL, "token"), "5f8a1b2c3d4e5f6a7b8c9d0e")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "5f8a1b2c3d4e5f6a7b8c9d11", settings.TLSProfile)
+			assert.Equal(t, "5f8a1b2c3d4e5f6a7b8c9d22", settings.DeliveryRegion)
+		})
+	}
+}
+
+func TestReadSourceSettings_UnexpectedReference(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tlsProfile": 5}`))
+	}))
+	defer server.Close()
+
+	_, err := readSourceSettings(context.Background(), apiclient.New(server.Client(), server.URL, "token"), "svc-1")
+
+	assert.ErrorContains(t, err, "tlsProfile")
+}
@@ -3,6 +3,7 @@ package resources_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -40,6 +41,16 @@ func TestServiceResourceSchema(t *testing.T) {
 	assert.Contains(t, attrs, "description")
 	assert.Contains(t, attrs, "auto_ssl")
 	assert.Contains(t, attrs, "configuration_mode")
+	assert.Contains(t, attrs, "options_removal_behavior")
+	assert.Contains(t, attrs, "exclusive_options")
+	assert.Contains(t, attrs, "source_service_id")
+	assert.Contains(t, attrs, "adopt_existing")
+	assert.Contains(t, attrs, "deletion_policy")
+	assert.Contains(t, attrs, "deletion_protection")
+	assert.Contains(t, attrs, "wait_for_deployment")
+	assert.Contains(t, resp.Schema.Blocks, "timeouts")
+	assert.Contains(t, attrs, "unmanaged_options")
+	assert.Contains(t, attrs, "source_baseline")
 
 	// computed attributes exist
 	assert.Contains(t, attrs, "status")
@@ -160,6 +171,7 @@ func TestAccServiceResourceWithOptions(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "unique_name", rName+"-unique"),
 					resource.TestCheckResourceAttr(resourceName, "options.%", "4"),
+					resource.TestCheckResourceAttrSet(resourceName, "unmanaged_options.%"),
 				),
 			},
 			// Update service options (test differential updates)
@@ -182,6 +194,71 @@ func TestAccServiceResourceWithOptions(t *testing.T) {
 	})
 }
 
+// An option changed outside Terraform is listed in unmanaged_options, and
+// with exclusive_options it shows up as drift until it is reset.
+func TestAccServiceResourceUnmanagedOptions(t *testing.T) {
+	rName := "test-unmanaged-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "cachefly_service." + rName
+
+	var serviceID string
+	setOutsideOption := func() {
+		sdkClient := provider.GetSDKClient()
+		if sdkClient == nil {
+			t.Fatal("Failed to create CacheFly client")
+		}
+		if _, err := sdkClient.ServiceOptions.UpdateOptions(context.Background(), serviceID, v2_6.ServiceOptions{"cors": true}); err != nil {
+			t.Fatalf("Failed to set cors outside Terraform: %v", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceResourceConfigWithExclusiveOptions(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceExists(resourceName),
+					func(s *terraform.State) error {
+						serviceID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+					resource.TestCheckNoResourceAttr(resourceName, "unmanaged_options.cors"),
+				),
+			},
+			// cors is not in the configuration, so it is only listed.
+			{
+				PreConfig: setOutsideOption,
+				Config:    testAccServiceResourceConfigWithExclusiveOptions(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "unmanaged_options.cors", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "options.cors"),
+				),
+			},
+			// Switching exclusive_options on makes the next refresh report
+			// cors as drift.
+			{
+				Config:             testAccServiceResourceConfigWithExclusiveOptions(rName, true),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:             testAccServiceResourceConfigWithExclusiveOptions(rName, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying resets cors, after which the plan is empty.
+			{
+				Config: testAccServiceResourceConfigWithExclusiveOptions(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "unmanaged_options.cors"),
+					resource.TestCheckNoResourceAttr(resourceName, "options.cors"),
+				),
+			},
+		},
+	})
+}
+
 // Test import scenario where all options should be loaded
 func TestAccServiceResourceImportWithOptions(t *testing.T) {
 	rName := "test-import-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
@@ -209,6 +286,154 @@ func TestAccServiceResourceImportWithOptions(t *testing.T) {
 					"options",
 				},
 			},
+			// The imported state holds every non-default option
+			{
+				ResourceName: "cachefly_service." + rName,
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported service, got %d", len(states))
+					}
+					for key, want := range map[string]string{
+						"options.autoRedirect":           "true",
+						"options.protectServeKeyEnabled": "true",
+						"options.reverseProxy.hostname":  "abc.com",
+					} {
+						if got := states[0].Attributes[key]; got != want {
+							return fmt.Errorf("expected imported %s to be %q, got %q", key, want, got)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceInvalidOptions(t *testing.T) {
+	rName := "test-invalid-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccServiceResourceConfigWithInvalidOptions(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Expected one of FOLLOW, HTTP, HTTPS`),
+			},
+			{
+				Config:      testAccServiceResourceConfigWithConfigurationMode(rName, "CONSOLE_ONLY"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Configuration Mode`),
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceRejectedOptions(t *testing.T) {
+	rName := "test-rejected-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The option is not in the built-in catalog, so it is only
+				// rejected by the API during apply.
+				Config:      testAccServiceResourceConfigWithRejectedOptions(rName),
+				ExpectError: regexp.MustCompile(`Service Options Validation Failed`),
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceAdoptExisting(t *testing.T) {
+	rName := "test-adopt-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceResourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceExists("cachefly_service." + rName),
+				),
+			},
+			// An active service is not adopted, since it may be managed
+			// elsewhere.
+			{
+				Config:      testAccServiceResourceConfig(rName) + testAccServiceResourceConfigAdoptExisting(rName),
+				ExpectError: regexp.MustCompile(`CacheFly Service Already Exists`),
+			},
+			// Destroying the first resource deactivates the service.
+			{
+				Config: `provider "cachefly" {}`,
+			},
+			// A new resource with the same unique_name adopts the deactivated
+			// service instead of failing to create it.
+			{
+				Config: `provider "cachefly" {}` + testAccServiceResourceConfigAdoptExisting(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceExists("cachefly_service.adopted"),
+					resource.TestCheckResourceAttr("cachefly_service.adopted", "description", "adopted"),
+					resource.TestCheckResourceAttr("cachefly_service.adopted", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceFromSource(t *testing.T) {
+	rName := "test-source-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	copyName := "cachefly_service." + rName + "-copy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkServiceDestroy,
+		Steps: []resource.TestStep{
+			// The copy takes its options from the source and overrides one.
+			{
+				Config: testAccServiceResourceConfigWithOptions(rName) + testAccServiceResourceConfigFromSource(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceExists(copyName),
+					resource.TestCheckResourceAttrPair(copyName, "source_service_id", "cachefly_service."+rName, "id"),
+					resource.TestCheckResourceAttr(copyName, "options.autoRedirect", "false"),
+					resource.TestCheckResourceAttrSet(copyName, "source_baseline.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceWaitForDeployment(t *testing.T) {
+	rName := "test-wait-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "cachefly_service." + rName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkServiceDestroy,
+		Steps: []resource.TestStep{
+			// The secret key is never returned by the API, so the wait must
+			// finish without it well before the timeout.
+			{
+				Config: testAccServiceResourceConfigWaitForDeployment(rName, "abc.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "options.reverseProxy.hostname", "abc.com"),
+				),
+			},
+			{
+				Config: testAccServiceResourceConfigWaitForDeployment(rName, "def.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "options.reverseProxy.hostname", "def.com"),
+				),
+			},
 		},
 	})
 }
@@ -352,3 +577,134 @@ resource "cachefly_service" %[1]q {
 }
 `, name)
 }
+
+// Test configuration for service with options that fail validation
+func testAccServiceResourceConfigWithInvalidOptions(name string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name        = %[1]q
+  unique_name = "%[1]s-unique"
+
+  options = {
+    reverseProxy = {
+      enabled           = true
+      originScheme      = "FTP"
+      cacheByQueryParam = true
+      useRobotsTxt      = true
+      ttl               = 123
+      hostname          = "abc.com"
+    }
+  }
+}
+`, name)
+}
+
+// Test configuration for service with an option the API rejects
+func testAccServiceResourceConfigWithRejectedOptions(name string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name        = %[1]q
+  unique_name = "%[1]s-unique"
+
+  options = {
+    notARealOption = true
+  }
+}
+`, name)
+}
+
+// Test configuration for a service that adopts an existing one
+func testAccServiceResourceConfigAdoptExisting(name string) string {
+	return fmt.Sprintf(`
+resource "cachefly_service" "adopted" {
+  name           = %[1]q
+  unique_name    = "%[1]s-unique"
+  description    = "adopted"
+  adopt_existing = true
+}
+`, name)
+}
+
+// Test configuration for a service copied from another one
+func testAccServiceResourceConfigFromSource(name string) string {
+	return fmt.Sprintf(`
+resource "cachefly_service" "%[1]s-copy" {
+  name              = "%[1]s-copy"
+  unique_name       = "%[1]s-copy-unique"
+  source_service_id = cachefly_service.%[1]s.id
+
+  options = {
+    autoRedirect = false
+  }
+}
+`, name)
+}
+
+// Test configuration for service with a configuration mode
+func testAccServiceResourceConfigWithConfigurationMode(name, mode string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name               = %[1]q
+  unique_name        = "%[1]s-unique"
+  configuration_mode = %[2]q
+}
+`, name, mode)
+}
+
+// Test configuration for a service that waits for its deployment
+func testAccServiceResourceConfigWaitForDeployment(name, hostname string) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name                = %[1]q
+  unique_name         = "%[1]s-unique"
+  wait_for_deployment = true
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+  }
+
+  options = {
+    reverseProxy = {
+      enabled           = true
+      mode              = "OBJECT_STORAGE"
+      hostname          = %[2]q
+      accessKey         = "access"
+      secretKey         = "secret"
+      region            = "us-east-1"
+      originScheme      = "HTTPS"
+      cacheByQueryParam = true
+      useRobotsTxt      = true
+      ttl               = 123
+    }
+  }
+}
+`, name, hostname)
+}
+
+// Test configuration for a service that manages one option, with
+// exclusive_options set as given
+func testAccServiceResourceConfigWithExclusiveOptions(name string, exclusive bool) string {
+	return fmt.Sprintf(`
+provider "cachefly" {}
+
+resource "cachefly_service" %[1]q {
+  name              = %[1]q
+  unique_name       = "%[1]s-unique"
+  description       = "%[1]s description"
+  exclusive_options = %[2]t
+
+  options = {
+    autoRedirect = true
+  }
+}
+`, name, exclusive)
+}
@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+)
+
+// serviceUpdate tracks the steps of a service update. The update takes
+// several API calls; partial is the prior state plus the steps that
+// completed, so that a failure part way through saves a state matching what
+// changed on CacheFly.
+type serviceUpdate struct {
+	serviceID string
+	partial   models.ServiceResourceModel
+	completed []string
+}
+
+func newServiceUpdate(plan, state models.ServiceResourceModel) *serviceUpdate {
+	partial := state
+	// These attributes only change the provider's behaviour, so they take
+	// effect without an API call.
+	partial.OptionsRemovalBehavior = plan.OptionsRemovalBehavior
+	partial.ExclusiveOptions = plan.ExclusiveOptions
+	partial.AdoptExisting = plan.AdoptExisting
+	partial.SourceServiceID = plan.SourceServiceID
+	partial.DeletionPolicy = plan.DeletionPolicy
+	partial.DeletionProtection = plan.DeletionProtection
+	partial.WaitForDeployment = plan.WaitForDeployment
+	partial.Timeouts = plan.Timeouts
+
+	return &serviceUpdate{
+		serviceID: plan.ID.ValueString(),
+		partial:   partial,
+	}
+}
+
+// done records that step completed.
+func (u *serviceUpdate) done(step string) {
+	u.completed = append(u.completed, step)
+}
+
+// failed reports that step failed after others completed, and returns the
+// state to save. It returns false if no step completed, in which case the
+// prior state is kept.
+func (u *serviceUpdate) failed(step string, diags *diag.Diagnostics) (models.ServiceResourceModel, bool) {
+	if len(u.completed) == 0 {
+		return u.partial, false
+	}
+
+	diags.AddWarning(
+		"CacheFly Service Partially Updated",
+		fmt.Sprintf("Service ID %s was only partially updated.\n\nCompleted steps: %s\nFailed step: %s\n\n"+
+			"The Terraform state was saved with the completed changes, so the next plan shows only what is left to apply.",
+			u.serviceID, strings.Join(u.completed, ", "), step),
+	)
+	return u.partial, true
+}
+
+// waitFailed returns the state to save when every step completed but the
+// wait for the deployment did not. The changes were all made, so planned is
+// saved as it is; the wait error itself is already in the diagnostics.
+func (u *serviceUpdate) waitFailed(planned models.ServiceResourceModel) models.ServiceResourceModel {
+	// The unmanaged options are not read again until the next refresh.
+	planned.UnmanagedOptions = u.partial.UnmanagedOptions
+	return planned
+}
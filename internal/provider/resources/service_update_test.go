@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+)
+
+func testServiceOptions(autoRedirect bool) types.Dynamic {
+	return types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"autoRedirect": types.BoolType},
+		map[string]attr.Value{"autoRedirect": types.BoolValue(autoRedirect)},
+	))
+}
+
+func testServiceUpdate() (models.ServiceResourceModel, models.ServiceResourceModel) {
+	state := models.ServiceResourceModel{
+		ID:               types.StringValue("svc-1"),
+		Description:      types.StringValue("old"),
+		Options:          testServiceOptions(false),
+		UnmanagedOptions: testServiceOptions(true),
+	}
+
+	plan := state
+	plan.Description = types.StringValue("new")
+	plan.Options = testServiceOptions(true)
+	plan.UnmanagedOptions = types.DynamicUnknown()
+	plan.WaitForDeployment = types.BoolValue(true)
+
+	return plan, state
+}
+
+func TestServiceUpdate_OptionsStepFails(t *testing.T) {
+	plan, state := testServiceUpdate()
+
+	update := newServiceUpdate(plan, state)
+	update.done("update service settings")
+	update.partial.Description = plan.Description
+
+	var diags diag.Diagnostics
+	saved, ok := update.failed("update service options", &diags)
+
+	assert.True(t, ok)
+	assert.Equal(t, "new", saved.Description.ValueString())
+	assert.True(t, saved.Options.Equal(state.Options), "options that failed to update keep their prior state")
+	assert.True(t, saved.WaitForDeployment.ValueBool())
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+		assert.Equal(t, "CacheFly Service Partially Updated", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Completed steps: update service settings")
+		assert.Contains(t, diags[0].Detail(), "Failed step: update service options")
+	}
+}
+
+func TestServiceUpdate_FirstStepFails(t *testing.T) {
+	plan, state := testServiceUpdate()
+
+	var diags diag.Diagnostics
+	_, ok := newServiceUpdate(plan, state).failed("update service settings", &diags)
+
+	assert.False(t, ok, "nothing changed, so the prior state is kept")
+	assert.Empty(t, diags)
+}
+
+func TestServiceUpdate_WaitFails(t *testing.T) {
+	plan, state := testServiceUpdate()
+
+	update := newServiceUpdate(plan, state)
+	update.done("update service settings")
+	update.done("update service options")
+
+	saved := update.waitFailed(plan)
+
+	assert.Equal(t, "new", saved.Description.ValueString())
+	assert.True(t, saved.Options.Equal(plan.Options), "every change was made, so the planned options are saved")
+	assert.True(t, saved.UnmanagedOptions.Equal(state.UnmanagedOptions))
+}
+
+func testServiceUpdateRequest(t *testing.T, plan, state models.ServiceResourceModel) fwresource.UpdateRequest {
+	priorState := testServiceSchemaState(t, state)
+	planned := testServiceSchemaState(t, plan)
+	return fwresource.UpdateRequest{
+		State: priorState,
+		Plan:  tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+	}
+}
+
+func TestServiceResourceUpdate_Settings(t *testing.T) {
+	tests := map[string]struct {
+		description string
+		wantPut     bool
+	}{
+		// Only deletion_policy changes, which takes effect without an API
+		// call, so no settings update is sent.
+		"unchanged": {description: "", wantPut: false},
+		"changed":   {description: "new", wantPut: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeServiceAPI{getStatus: http.StatusOK}
+
+			state := testServiceModel(deletionPolicyDeactivate, false)
+			state.Description = types.StringValue("")
+			plan := state
+			plan.DeletionPolicy = types.StringValue(deletionPolicyDelete)
+			plan.Description = types.StringValue(test.description)
+
+			req := testServiceUpdateRequest(t, plan, state)
+			resp := fwresource.UpdateResponse{State: req.State}
+			fake.resource(t).Update(context.Background(), req, &resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.Equal(t, test.wantPut, slices.Contains(fake.requests, "PUT /services/svc-1"), "requests: %v", fake.requests)
+
+			var saved models.ServiceResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &saved)...)
+			assert.Equal(t, deletionPolicyDelete, saved.DeletionPolicy.ValueString())
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/wait"
+)
+
+// defaultDeploymentTimeout is how long Create and Update wait for a service
+// deployment when no timeout is configured.
+const defaultDeploymentTimeout = 20 * time.Minute
+
+// deploymentTimeout returns the configured timeout for the operation
+// ("create" or "update").
+func deploymentTimeout(timeouts *models.ServiceTimeoutsModel, operation string) (time.Duration, error) {
+	if timeouts == nil {
+		return defaultDeploymentTimeout, nil
+	}
+
+	value := timeouts.Create
+	if operation == "update" {
+		value = timeouts.Update
+	}
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultDeploymentTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", value.ValueString())
+	}
+	return timeout, nil
+}
+
+// validateTimeouts reports timeouts that are not valid durations.
+func validateTimeouts(timeouts *models.ServiceTimeoutsModel, diags *diag.Diagnostics) {
+	for _, operation := range []string{"create", "update"} {
+		if _, err := deploymentTimeout(timeouts, operation); err != nil {
+			diags.AddAttributeError(
+				path.Root("timeouts").AtName(operation),
+				"Invalid Timeout",
+				fmt.Sprintf("The %s timeout must be a duration such as \"30m\" or \"1h\": %s", operation, err),
+			)
+		}
+	}
+}
+
+// waitForDeployment polls the service until it has the planned status and
+// the API reports the options in applied, then maps the final service to
+// data. applied holds the values the API returned when the options were
+// written, and may be nil if none were. wantStatus may be empty to skip the
+// status check.
+func (r *ServiceResource) waitForDeployment(ctx context.Context, data *models.ServiceResourceModel, operation, wantStatus string, applied api.ServiceOptions, diags *diag.Diagnostics) {
+	serviceID := data.ID.ValueString()
+
+	timeout, err := deploymentTimeout(data.Timeouts, operation)
+	if err != nil {
+		diags.AddAttributeError(path.Root("timeouts").AtName(operation), "Invalid Timeout", err.Error())
+		return
+	}
+
+	var catalog serviceoptions.Catalog
+	if len(applied) > 0 {
+		catalog = r.optionsCatalog(ctx, serviceID)
+	}
+
+	var service *api.Service
+
+	condition := func(ctx context.Context) (bool, string, error) {
+		current, err := r.client.Services.GetByID(ctx, serviceID)
+		if err != nil {
+			return false, "", err
+		}
+		service = current
+
+		var pending []string
+		if wantStatus != "" && current.Status != wantStatus {
+			pending = append(pending, fmt.Sprintf("status is %s, waiting for %s", current.Status, wantStatus))
+		}
+
+		if len(applied) > 0 {
+			apiOptions, err := r.client.ServiceOptions.GetOptions(ctx, serviceID)
+			if err != nil {
+				return false, "", err
+			}
+			if keys := catalog.Pending(applied, apiOptions); len(keys) > 0 {
+				pending = append(pending, "options not applied yet: "+strings.Join(keys, ", "))
+			}
+		}
+
+		return len(pending) == 0, strings.Join(pending, "; "), nil
+	}
+
+	onPoll := func(attempt int, progress string) {
+		tflog.Info(ctx, "Waiting for service deployment", map[string]interface{}{
+			"service_id": serviceID,
+			"attempt":    attempt,
+			"pending":    progress,
+		})
+	}
+
+	start := time.Now()
+	if err := wait.Until(ctx, timeout, wait.DefaultBackoff, condition, onPoll); err != nil {
+		var timeoutErr *wait.TimeoutError
+		if errors.As(err, &timeoutErr) {
+			diags.AddError(
+				"Timed Out Waiting for CacheFly Service Deployment",
+				fmt.Sprintf("Service ID %s was not fully deployed within the %s timeout (%d checks): %s. "+
+					"The changes were sent to CacheFly and may still be applied. Increase timeouts.%s if deployments take longer than that.",
+					serviceID, timeoutErr.Timeout, timeoutErr.Attempts, timeoutErr.Progress, operation),
+			)
+			return
+		}
+
+		diags.Append(apierrors.Diagnostic(
+			"Error Waiting for CacheFly Service Deployment",
+			"Could not check the deployment of service ID "+serviceID,
+			err,
+		))
+		return
+	}
+
+	tflog.Info(ctx, "Service deployment finished", map[string]interface{}{
+		"service_id": serviceID,
+		"duration":   time.Since(start).String(),
+	})
+
+	if service != nil {
+		r.mapServiceToState(service, data)
+	}
+}
+
+// wantedStatus returns the status the plan asks for, or an empty string if
+// it leaves the status to the API.
+func wantedStatus(status types.String) string {
+	if status.IsNull() || status.IsUnknown() {
+		return ""
+	}
+	return status.ValueString()
+}
@@ -3,7 +3,6 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -17,7 +16,9 @@ import (
 	"github.com/cachefly/cachefly-sdk-go/pkg/cachefly"
 	api "github.com/cachefly/cachefly-sdk-go/pkg/cachefly/api/v2_6"
 
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
 	"github.com/cachefly/terraform-provider-cachefly/internal/provider/models"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/providerdata"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -119,16 +120,16 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*cachefly.Client)
+	providerData, ok := req.ProviderData.(*providerdata.CacheFlyClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cachefly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.CacheFlyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 // Create creates the resource and sets the initial Terraform state
@@ -178,10 +179,11 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	user, err := r.client.Users.Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Creating CacheFly User",
-			"Could not create user, unexpected error: "+err.Error(),
-		)
+			"Could not create user, unexpected error",
+			err,
+		))
 		return
 	}
 
@@ -204,13 +206,14 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	user, err := r.client.Users.GetByID(ctx, userID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if apierrors.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(apierrors.Diagnostic(
 				"Error Reading CacheFly User",
-				"Could not read user with ID "+userID+": "+err.Error(),
-			)
+				"Could not read user with ID "+userID,
+				err,
+			))
 		}
 		return
 	}
@@ -277,10 +280,11 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	user, err := r.client.Users.UpdateByID(ctx, userID, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Updating CacheFly User",
-			"Could not update user with ID "+userID+": "+err.Error(),
-		)
+			"Could not update user with ID "+userID,
+			err,
+		))
 		return
 	}
 
@@ -302,10 +306,11 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	err := r.client.Users.DeleteByID(ctx, userID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(apierrors.Diagnostic(
 			"Error Deleting CacheFly User",
-			"Could not delete user with ID "+userID+": "+err.Error(),
-		)
+			"Could not delete user with ID "+userID,
+			err,
+		))
 		return
 	}
 }
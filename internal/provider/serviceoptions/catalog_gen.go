@@ -0,0 +1,321 @@
+// Code generated by gen/main.go from metadata.json. DO NOT EDIT.
+
+package serviceoptions
+
+var builtinOptions = []Option{
+	{
+		Name:        "reverseProxy",
+		Title:       "Reverse Proxy",
+		Description: "Reverse proxy configuration. When `enabled = true`, the required fields below must be set.",
+		Type:        OptionTypeObject,
+		Fields: []Field{
+			{
+				Name:     "enabled",
+				Property: Property{Type: ValueTypeBoolean},
+				Required: true,
+			},
+			{
+				Name:        "hostname",
+				Description: "Origin hostname.",
+				Property:    Property{Type: ValueTypeString},
+				Required:    true,
+			},
+			{
+				Name:        "originScheme",
+				Description: "Scheme used to connect to the origin.",
+				Property:    Property{Type: ValueTypeString, Enum: []string{"FOLLOW", "HTTP", "HTTPS"}},
+				Required:    true,
+			},
+			{
+				Name:        "ttl",
+				Description: "Cache TTL in seconds.",
+				Property:    Property{Type: ValueTypeInteger, Min: floatPtr(0)},
+				Required:    true,
+			},
+			{
+				Name:     "useRobotsTxt",
+				Property: Property{Type: ValueTypeBoolean},
+				Required: true,
+			},
+			{
+				Name:     "cacheByQueryParam",
+				Property: Property{Type: ValueTypeBoolean},
+				Required: true,
+			},
+			{
+				Name:        "mode",
+				Description: "Origin type.",
+				Property:    Property{Type: ValueTypeString, Enum: []string{"WEB", "OBJECT_STORAGE"}},
+			},
+			{
+				Name:         "accessKey",
+				Description:  "Object storage access key.",
+				Property:     Property{Type: ValueTypeString, Sensitive: true},
+				RequiredWhen: &Condition{Field: "mode", Value: "OBJECT_STORAGE"},
+			},
+			{
+				Name:         "secretKey",
+				Description:  "Object storage secret key.",
+				Property:     Property{Type: ValueTypeString, Sensitive: true},
+				RequiredWhen: &Condition{Field: "mode", Value: "OBJECT_STORAGE"},
+			},
+			{
+				Name:         "region",
+				Description:  "Object storage region.",
+				Property:     Property{Type: ValueTypeString},
+				RequiredWhen: &Condition{Field: "mode", Value: "OBJECT_STORAGE"},
+			},
+			{
+				Name:        "prepend",
+				Description: "Path to prepend to origin requests.",
+				Property:    Property{Type: ValueTypeString},
+			},
+			{
+				Name:        "authorization",
+				Description: "Origin authorization, e.g. `{ type = \"NONE\" }`.",
+				Property:    Property{Type: ValueTypeObject},
+			},
+		},
+	},
+	{
+		Name:        "allow_encoding_ext",
+		Title:       "Allow Encoding Extensions",
+		Description: "File extensions to allow content encoding for.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeStringList},
+	},
+	{
+		Name:        "bwthrottle",
+		Title:       "Bandwidth Throttle",
+		Description: "Bandwidth throttle rate.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(0)},
+	},
+	{
+		Name:        "bwthrottlequery",
+		Title:       "Bandwidth Throttle Query",
+		Description: "Query parameters that control bandwidth throttling.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeStringList},
+	},
+	{
+		Name:        "contimeout",
+		Title:       "Connect Timeout",
+		Description: "Connect timeout in seconds.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(1)},
+	},
+	{
+		Name:        "custom_server_label",
+		Title:       "Custom Server Label",
+		Description: "Value of the Server response header.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeString},
+	},
+	{
+		Name:        "dirpurgeskip",
+		Title:       "Directory Purge Skip",
+		Description: "Number of directory levels to skip when purging.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(0)},
+	},
+	{
+		Name:        "error_ttl",
+		Title:       "Error TTL",
+		Description: "Cache TTL for error responses in seconds.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(0)},
+	},
+	{
+		Name:        "httpmethods",
+		Title:       "HTTP Methods",
+		Description: "Per-method allow flags, e.g. `{ GET = true, POST = false }`.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeObject},
+	},
+	{
+		Name:        "maxcons",
+		Title:       "Max Connections",
+		Description: "Maximum number of concurrent connections to the origin.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(1)},
+	},
+	{
+		Name:        "originhostheader",
+		Title:       "Origin Host Header",
+		Description: "Host headers sent to the origin.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeStringList},
+	},
+	{
+		Name:        "purgemode",
+		Title:       "Purge Mode",
+		Description: "Purge mode. Either a string code such as \"2\" or object flags such as `{ exact = true, directory = true, extension = true }`.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeAny},
+	},
+	{
+		Name:        "redirect",
+		Title:       "Redirect",
+		Description: "Redirect URL.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeString},
+	},
+	{
+		Name:        "sharedshield",
+		Title:       "Shared Shield",
+		Description: "Shield location, e.g. \"ORD\".",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeString},
+	},
+	{
+		Name:        "skip_encoding_ext",
+		Title:       "Skip Encoding Extensions",
+		Description: "File extensions to skip content encoding for.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeStringList},
+	},
+	{
+		Name:        "skip_pserve_ext",
+		Title:       "Skip ProtectServe Extensions",
+		Description: "File extensions to skip ProtectServe for.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeStringList},
+	},
+	{
+		Name:        "ttfb_timeout",
+		Title:       "TTFB Timeout",
+		Description: "Time-to-first-byte timeout in seconds.",
+		Type:        OptionTypeStandard,
+		Value:       &Property{Type: ValueTypeInteger, Min: floatPtr(1)},
+	},
+	{
+		Name:        "allowretry",
+		Description: "Allows retrying failed origin requests.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "autoRedirect",
+		Title:       "Auto HTTPS Redirect",
+		Description: "Automatically redirects HTTP to HTTPS.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "brotli_compression",
+		Description: "Enables Brotli compression at the edge.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "brotli_support",
+		Description: "Enables Brotli content negotiation support.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "cachebygeocountry",
+		Description: "Varies cache by geo country.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "cachebyreferer",
+		Description: "Varies cache by HTTP Referer.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "cachebyregion",
+		Description: "Varies cache by region.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "cachepostrequests",
+		Description: "Enables caching of POST requests.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "cors",
+		Title:       "CORS Override",
+		Description: "Enables CORS override for the service.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "edgetoorigin",
+		Description: "Sends requests directly from edge to origin for certain flows.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "followredirect",
+		Description: "Follows origin redirects.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "forceorigqstring",
+		Description: "Forces original query string to origin.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "hsts",
+		Description: "Enables HTTP Strict Transport Security.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "linkpreheat",
+		Description: "Enables link preheating.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "livestreaming",
+		Description: "Enables live streaming optimizations.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "nocache",
+		Description: "Disables caching of responses.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "normalizequerystring",
+		Description: "Normalizes query strings for cache keys.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "protectServeKeyEnabled",
+		Title:       "ProtectServe",
+		Description: "Enables ProtectServe. When set to true, the provider regenerates the ProtectServe key; when set to false, the key is deleted.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "purgenoquery",
+		Description: "Ignores query string when purging.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "referrerBlocking",
+		Title:       "Referrer Blocking",
+		Description: "Blocks requests based on referrer rules.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "send-xff",
+		Description: "Sends X-Forwarded-For header. The key contains a hyphen, so quote it in HCL: `\"send-xff\" = true`.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "servestale",
+		Description: "Serves stale content when origin is unavailable.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "skip_encoding",
+		Description: "Skips content encoding.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "skip_urlencoding",
+		Description: "Skips URL encoding on the edge.",
+		Type:        OptionTypeBoolean,
+	},
+	{
+		Name:        "usecfdootencoding",
+		Description: "Enables CF dot-encoding handling.",
+		Type:        OptionTypeBoolean,
+	},
+}
@@ -0,0 +1,83 @@
+package serviceoptions
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apiclient"
+)
+
+// Client fetches the options metadata of services and remembers it for the
+// lifetime of the provider, so each service is looked up at most once per
+// Terraform run.
+type Client struct {
+	api *apiclient.Client
+
+	// mu guards the maps only; it is not held while fetching, so lookups of
+	// different services run in parallel.
+	mu       sync.Mutex
+	catalogs map[string]Catalog
+	inflight map[string]*metadataFetch
+}
+
+// metadataFetch is a metadata lookup in progress. Concurrent callers for the
+// same service wait for it instead of sending their own request.
+type metadataFetch struct {
+	done    chan struct{}
+	catalog Catalog
+	err     error
+}
+
+// NewClient returns a Client that uses httpClient, so metadata lookups share
+// the retry and limit handling of the SDK client.
+func NewClient(httpClient *http.Client, baseURL, token string) *Client {
+	return &Client{
+		api:      apiclient.New(httpClient, baseURL, token),
+		catalogs: make(map[string]Catalog),
+		inflight: make(map[string]*metadataFetch),
+	}
+}
+
+// Metadata returns the catalog of options supported by the service. Failed
+// lookups are not remembered, so the next call tries again.
+func (c *Client) Metadata(ctx context.Context, serviceID string) (Catalog, error) {
+	c.mu.Lock()
+	if catalog, ok := c.catalogs[serviceID]; ok {
+		c.mu.Unlock()
+		return catalog, nil
+	}
+	if fetch, ok := c.inflight[serviceID]; ok {
+		c.mu.Unlock()
+		select {
+		case <-fetch.done:
+			return fetch.catalog, fetch.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	fetch := &metadataFetch{done: make(chan struct{})}
+	c.inflight[serviceID] = fetch
+	c.mu.Unlock()
+
+	fetch.catalog, fetch.err = c.fetchMetadata(ctx, serviceID)
+
+	c.mu.Lock()
+	delete(c.inflight, serviceID)
+	if fetch.err == nil {
+		c.catalogs[serviceID] = fetch.catalog
+	}
+	c.mu.Unlock()
+	close(fetch.done)
+
+	return fetch.catalog, fetch.err
+}
+
+func (c *Client) fetchMetadata(ctx context.Context, serviceID string) (Catalog, error) {
+	var body []byte
+	if err := c.api.Get(ctx, "/services/"+url.PathEscape(serviceID)+"/options/metadata", &body); err != nil {
+		return nil, err
+	}
+	return ParseMetadata(body)
+}
@@ -0,0 +1,118 @@
+package serviceoptions_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestClient_Metadata(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/api/2.6/services/svc-1/options/metadata":
+			_, _ = w.Write([]byte(`{"options":[{"name":"cors","type":"boolean"},{"name":"error_ttl","type":"standard","value":{"type":"integer","min":0}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := serviceoptions.NewClient(server.Client(), server.URL+"/api/2.6/", "test-token")
+
+	catalog, err := client.Metadata(context.Background(), "svc-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"cors", "error_ttl"}, catalog.Names())
+	}
+
+	// The metadata is cached per service.
+	_, err = client.Metadata(context.Background(), "svc-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	_, err = client.Metadata(context.Background(), "svc-2")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestClient_MetadataRejectsUnknownOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"options":[{"name":"cors","type":"boolean"}]}`))
+	}))
+	defer server.Close()
+
+	client := serviceoptions.NewClient(server.Client(), server.URL, "test-token")
+
+	catalog, err := client.Metadata(context.Background(), "svc-1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	problems := catalog.Validate(map[string]interface{}{"cors": true, "hsts": true}, serviceoptions.ValidateOptions{RejectUnknown: true})
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, "hsts", problems[0].Option)
+	}
+}
+
+func TestClient_MetadataWithoutOptions(t *testing.T) {
+	for _, body := range []string{`{}`, `{"options":[]}`, `{"data":{"options":[]}}`} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+
+		client := serviceoptions.NewClient(server.Client(), server.URL, "test-token")
+
+		_, err := client.Metadata(context.Background(), "svc-1")
+		assert.ErrorContains(t, err, "lists no options", "body %s", body)
+
+		server.Close()
+	}
+}
+
+func TestClient_MetadataConcurrent(t *testing.T) {
+	var requests int32
+	slowStarted := make(chan struct{})
+	releaseSlow := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/services/slow/options/metadata" {
+			close(slowStarted)
+			<-releaseSlow
+		}
+		_, _ = w.Write([]byte(`{"options":[{"name":"cors","type":"boolean"}]}`))
+	}))
+	defer server.Close()
+
+	client := serviceoptions.NewClient(server.Client(), server.URL, "test-token")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Metadata(context.Background(), "slow")
+			assert.NoError(t, err)
+		}()
+	}
+
+	// A lookup of another service does not wait for the slow one.
+	<-slowStarted
+	_, err := client.Metadata(context.Background(), "fast")
+	assert.NoError(t, err)
+
+	close(releaseSlow)
+	wg.Wait()
+
+	// The concurrent lookups of the slow service shared one request.
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,91 @@
+package serviceoptions
+
+import (
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// unknown marks a value that is not known until apply.
+type unknown struct{}
+
+// IsUnknown reports whether value, as produced by FromValue, is not known
+// until apply.
+func IsUnknown(value interface{}) bool {
+	_, ok := value.(unknown)
+	return ok
+}
+
+// FromValue converts a Terraform value into plain Go values for validation.
+// Unlike the conversion used to build API requests, unknown values are kept
+// as a marker so that validation can tell "not set" apart from "not known
+// yet", and nested null values are dropped.
+func FromValue(value attr.Value) interface{} {
+	if value == nil || value.IsNull() {
+		return nil
+	}
+	if value.IsUnknown() {
+		return unknown{}
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return FromValue(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString()
+	case basetypes.BoolValue:
+		return v.ValueBool()
+	case basetypes.Int64Value:
+		return int(v.ValueInt64())
+	case basetypes.Float64Value:
+		return v.ValueFloat64()
+	case basetypes.NumberValue:
+		bigFloat := v.ValueBigFloat()
+		if bigFloat.IsInt() {
+			if val, accuracy := bigFloat.Int64(); accuracy == big.Exact {
+				return int(val)
+			}
+		}
+		val, _ := bigFloat.Float64()
+		return val
+	case basetypes.ListValue:
+		return fromElements(v.Elements())
+	case basetypes.TupleValue:
+		return fromElements(v.Elements())
+	case basetypes.SetValue:
+		return fromElements(v.Elements())
+	case basetypes.ObjectValue:
+		return fromAttributes(v.Attributes())
+	case basetypes.MapValue:
+		return fromAttributes(v.Elements())
+	}
+
+	return unknown{}
+}
+
+// MapFromValue converts the options attribute into a map of option values.
+// It returns false when the attribute is null, unknown or not an object.
+func MapFromValue(value attr.Value) (map[string]interface{}, bool) {
+	options, ok := FromValue(value).(map[string]interface{})
+	return options, ok
+}
+
+func fromElements(elements []attr.Value) []interface{} {
+	result := make([]interface{}, len(elements))
+	for i, elem := range elements {
+		result[i] = FromValue(elem)
+	}
+	return result
+}
+
+func fromAttributes(attributes map[string]attr.Value) map[string]interface{} {
+	result := make(map[string]interface{}, len(attributes))
+	for key, attribute := range attributes {
+		if converted := FromValue(attribute); converted != nil {
+			result[key] = converted
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package serviceoptions
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// DisabledValue returns the value that switches off an option whose current
+// value is current: false for boolean options and `{ enabled = false }` for
+// enabled/value and object options. It returns false if the shape of current
+// has no disabled form.
+func DisabledValue(current interface{}) (interface{}, bool) {
+	switch current.(type) {
+	case bool:
+		return false, true
+	case map[string]interface{}:
+		return map[string]interface{}{"enabled": false}, true
+	default:
+		return nil, false
+	}
+}
+
+// DefaultValue returns the default of the named option. It returns false if
+// the catalog does not know the default.
+func (c Catalog) DefaultValue(name string) (interface{}, bool) {
+	if option, ok := c[name]; ok && option.Default != nil {
+		return option.Default, true
+	}
+	return nil, false
+}
+
+// ResetValues returns the values that restore options to their defaults. It
+// also returns, sorted, the names of the options whose default is unknown;
+// they are left out of the values so they keep their current value rather
+// than being guessed at.
+func (c Catalog) ResetValues(options map[string]interface{}) (map[string]interface{}, []string) {
+	values := make(map[string]interface{}, len(options))
+	var unknown []string
+
+	for name := range options {
+		if value, ok := c.DefaultValue(name); ok {
+			values[name] = value
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+	return values, unknown
+}
+
+// IsDefault reports whether value is the default of the named option. When
+// the default is unknown, disabled options are treated as default.
+func (c Catalog) IsDefault(name string, value interface{}) bool {
+	if option, ok := c[name]; ok && option.Default != nil {
+		return equalJSON(option.Default, value)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case map[string]interface{}:
+		enabled, ok := v["enabled"].(bool)
+		return ok && !enabled
+	default:
+		return false
+	}
+}
+
+// equalJSON compares two values by their JSON encoding, so that numbers
+// compare equal regardless of their Go type.
+func equalJSON(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
@@ -0,0 +1,84 @@
+package serviceoptions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestDisabledValue(t *testing.T) {
+	value, ok := serviceoptions.DisabledValue(true)
+	assert.True(t, ok)
+	assert.Equal(t, false, value)
+
+	value, ok = serviceoptions.DisabledValue(map[string]interface{}{"enabled": true, "value": 5})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"enabled": false}, value)
+
+	_, ok = serviceoptions.DisabledValue("2")
+	assert.False(t, ok)
+}
+
+func TestCatalog_DefaultValue(t *testing.T) {
+	catalog, err := serviceoptions.ParseMetadata([]byte(`{"options":[
+		{"name":"error_ttl","type":"standard","value":{"type":"integer"},"default":{"enabled":true,"value":60}},
+		{"name":"cors","type":"boolean"}
+	]}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	value, ok := catalog.DefaultValue("error_ttl")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"enabled": true, "value": float64(60)}, value)
+
+	_, ok = catalog.DefaultValue("cors")
+	assert.False(t, ok)
+}
+
+func TestCatalog_ResetValues(t *testing.T) {
+	catalog, err := serviceoptions.ParseMetadata([]byte(`{"options":[
+		{"name":"error_ttl","type":"standard","value":{"type":"integer"},"default":{"enabled":true,"value":60}},
+		{"name":"cors","type":"boolean"}
+	]}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	values, unknown := catalog.ResetValues(map[string]interface{}{
+		"error_ttl":    map[string]interface{}{"enabled": true, "value": 5},
+		"cors":         true,
+		"ttfb_timeout": map[string]interface{}{"enabled": true, "value": 5},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"error_ttl": map[string]interface{}{"enabled": true, "value": float64(60)},
+	}, values)
+	// Options without a known default are left untouched, not disabled.
+	assert.Equal(t, []string{"cors", "ttfb_timeout"}, unknown)
+
+	// The built-in catalog knows no defaults, so nothing is reset.
+	values, unknown = serviceoptions.Builtin().ResetValues(map[string]interface{}{"cors": true})
+	assert.Empty(t, values)
+	assert.Equal(t, []string{"cors"}, unknown)
+}
+
+func TestCatalog_IsDefault(t *testing.T) {
+	catalog, err := serviceoptions.ParseMetadata([]byte(`{"options":[
+		{"name":"error_ttl","type":"standard","value":{"type":"integer"},"default":{"enabled":true,"value":60}}
+	]}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, catalog.IsDefault("error_ttl", map[string]interface{}{"enabled": true, "value": 60}))
+	assert.False(t, catalog.IsDefault("error_ttl", map[string]interface{}{"enabled": false}))
+
+	assert.True(t, catalog.IsDefault("cors", false))
+	assert.False(t, catalog.IsDefault("cors", true))
+	assert.True(t, catalog.IsDefault("ttfb_timeout", map[string]interface{}{"enabled": false, "value": 5}))
+	assert.False(t, catalog.IsDefault("sharedshield", map[string]interface{}{"enabled": true, "value": "ORD"}))
+	assert.False(t, catalog.IsDefault("purgemode", "2"))
+}
@@ -0,0 +1,9 @@
+package serviceoptions
+
+// The built-in catalog and the Options section of the cachefly_service docs
+// are generated from metadata.json. The file is maintained by hand: it lists
+// the documented options but no defaults or configuration modes, which only
+// the metadata of a service provides. To refresh it, replace it with the
+// response of the service options metadata endpoint and run `make generate`.
+//
+//go:generate go run ./gen
@@ -0,0 +1,359 @@
+// Command gen generates the built-in service options catalog and the Options
+// section of the cachefly_service docs from metadata.json. It is run by
+// `go generate` from the serviceoptions package directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+const (
+	metadataFile = "metadata.json"
+	catalogFile  = "catalog_gen.go"
+
+	docsBeginMarker = "<!-- BEGIN GENERATED OPTIONS -->"
+	docsEndMarker   = "<!-- END GENERATED OPTIONS -->"
+)
+
+var docsFiles = []string{
+	"../../../templates/resources/service.md",
+	"../../../docs/resources/service.md",
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("serviceoptions/gen: ")
+
+	data, err := os.ReadFile(metadataFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	catalog, err := serviceoptions.ParseMetadata(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	options := sortedOptions(catalog)
+
+	source, err := catalogSource(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(catalogFile, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	section := docsSection(options)
+	for _, filename := range docsFiles {
+		if err := replaceDocsSection(filename, section); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// sortedOptions orders options the way they are documented: object options
+// first, then enabled/value options, then boolean options, each by name.
+func sortedOptions(catalog serviceoptions.Catalog) []serviceoptions.Option {
+	rank := map[serviceoptions.OptionType]int{
+		serviceoptions.OptionTypeObject:   0,
+		serviceoptions.OptionTypeStandard: 1,
+		serviceoptions.OptionTypeBoolean:  2,
+	}
+
+	options := make([]serviceoptions.Option, 0, len(catalog))
+	for _, name := range catalog.Names() {
+		options = append(options, catalog[name])
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		return rank[options[i].Type] < rank[options[j].Type]
+	})
+
+	return options
+}
+
+func catalogSource(options []serviceoptions.Option) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by gen/main.go from metadata.json. DO NOT EDIT.\n\n")
+	b.WriteString("package serviceoptions\n\n")
+	b.WriteString("var builtinOptions = []Option{\n")
+	for _, option := range options {
+		b.WriteString("{\n")
+		fmt.Fprintf(&b, "Name: %q,\n", option.Name)
+		if option.Title != "" {
+			fmt.Fprintf(&b, "Title: %q,\n", option.Title)
+		}
+		if option.Description != "" {
+			fmt.Fprintf(&b, "Description: %q,\n", option.Description)
+		}
+		fmt.Fprintf(&b, "Type: %s,\n", optionTypeConstant(option.Type))
+		if option.Value != nil {
+			fmt.Fprintf(&b, "Value: &Property{%s},\n", propertyFields(*option.Value))
+		}
+		if option.Default != nil {
+			fmt.Fprintf(&b, "Default: %s,\n", goLiteral(option.Default))
+		}
+		if len(option.ConfigurationModes) > 0 {
+			fmt.Fprintf(&b, "ConfigurationModes: %#v,\n", option.ConfigurationModes)
+		}
+		if len(option.Fields) > 0 {
+			b.WriteString("Fields: []Field{\n")
+			for _, field := range option.Fields {
+				b.WriteString("{\n")
+				fmt.Fprintf(&b, "Name: %q,\n", field.Name)
+				if field.Description != "" {
+					fmt.Fprintf(&b, "Description: %q,\n", field.Description)
+				}
+				fmt.Fprintf(&b, "Property: Property{%s},\n", propertyFields(field.Property))
+				if field.Required {
+					b.WriteString("Required: true,\n")
+				}
+				if field.RequiredWhen != nil {
+					fmt.Fprintf(&b, "RequiredWhen: &Condition{Field: %q, Value: %q},\n", field.RequiredWhen.Field, field.RequiredWhen.Value)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func propertyFields(p serviceoptions.Property) string {
+	fields := []string{"Type: " + valueTypeConstant(p.Type)}
+	if len(p.Enum) > 0 {
+		quoted := make([]string, len(p.Enum))
+		for i, value := range p.Enum {
+			quoted[i] = strconv.Quote(value)
+		}
+		fields = append(fields, "Enum: []string{"+strings.Join(quoted, ", ")+"}")
+	}
+	if p.Min != nil {
+		fields = append(fields, "Min: floatPtr("+strconv.FormatFloat(*p.Min, 'g', -1, 64)+")")
+	}
+	if p.Max != nil {
+		fields = append(fields, "Max: floatPtr("+strconv.FormatFloat(*p.Max, 'g', -1, 64)+")")
+	}
+	if p.Sensitive {
+		fields = append(fields, "Sensitive: true")
+	}
+	return strings.Join(fields, ", ")
+}
+
+// goLiteral formats a value decoded from JSON as a Go expression.
+func goLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")"
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = goLiteral(item)
+		}
+		return "[]interface{}{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = strconv.Quote(key) + ": " + goLiteral(v[key])
+		}
+		return "map[string]interface{}{" + strings.Join(items, ", ") + "}"
+	default:
+		return "nil"
+	}
+}
+
+func optionTypeConstant(t serviceoptions.OptionType) string {
+	switch t {
+	case serviceoptions.OptionTypeBoolean:
+		return "OptionTypeBoolean"
+	case serviceoptions.OptionTypeStandard:
+		return "OptionTypeStandard"
+	default:
+		return "OptionTypeObject"
+	}
+}
+
+func valueTypeConstant(t serviceoptions.ValueType) string {
+	switch t {
+	case serviceoptions.ValueTypeBoolean:
+		return "ValueTypeBoolean"
+	case serviceoptions.ValueTypeString:
+		return "ValueTypeString"
+	case serviceoptions.ValueTypeInteger:
+		return "ValueTypeInteger"
+	case serviceoptions.ValueTypeNumber:
+		return "ValueTypeNumber"
+	case serviceoptions.ValueTypeStringList:
+		return "ValueTypeStringList"
+	case serviceoptions.ValueTypeObject:
+		return "ValueTypeObject"
+	default:
+		return "ValueTypeAny"
+	}
+}
+
+func docsSection(options []serviceoptions.Option) string {
+	var b strings.Builder
+
+	b.WriteString(docsBeginMarker + "\n")
+	b.WriteString("<!-- generated from internal/provider/serviceoptions/metadata.json, run `make generate` to update -->\n\n")
+
+	var booleans []serviceoptions.Option
+	for _, option := range options {
+		switch option.Type {
+		case serviceoptions.OptionTypeBoolean:
+			booleans = append(booleans, option)
+
+		case serviceoptions.OptionTypeStandard:
+			fmt.Fprintf(&b, "### %s (Object)\n\n", option.Name)
+			if option.Description != "" {
+				b.WriteString(option.Description + "\n\n")
+			}
+			b.WriteString(typedName(option.Name))
+			b.WriteString("- `enabled` (Boolean)\n")
+			if option.Value != nil {
+				fmt.Fprintf(&b, "- `value` (%s)%s\n", typeLabel(*option.Value), constraints(*option.Value, ""))
+			}
+			b.WriteString("\n")
+
+		case serviceoptions.OptionTypeObject:
+			fmt.Fprintf(&b, "### %s (Object)\n\n", option.Name)
+			if option.Description != "" {
+				b.WriteString(option.Description + "\n\n")
+			}
+			b.WriteString(typedName(option.Name))
+			for _, field := range option.Fields {
+				var requirement string
+				switch {
+				case field.Required:
+					requirement = "required"
+				case field.RequiredWhen != nil:
+					requirement = fmt.Sprintf("required when `%s = %q`", field.RequiredWhen.Field, field.RequiredWhen.Value)
+				default:
+					requirement = "optional"
+				}
+				fmt.Fprintf(&b, "- `%s`%s (%s)%s", field.Name, typedFieldName(field.Name), typeLabel(field.Property), constraints(field.Property, requirement))
+				if field.Description != "" {
+					b.WriteString(". " + field.Description)
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(booleans) > 0 {
+		b.WriteString("### Boolean options\n\n")
+		b.WriteString("These options are set directly to `true` or `false`.\n\n")
+		for _, option := range booleans {
+			fmt.Fprintf(&b, "- `%s`%s (Boolean)", option.Name, typedFieldName(option.Name))
+			if option.Description != "" {
+				b.WriteString(" " + option.Description)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(docsEndMarker)
+	return b.String()
+}
+
+// typedName notes the name of an option in typed_options, if it differs.
+func typedName(name string) string {
+	if attributeName := serviceoptions.AttributeName(name); attributeName != name {
+		return fmt.Sprintf("In `typed_options`, set it as `%s`.\n\n", attributeName)
+	}
+	return ""
+}
+
+// typedFieldName notes the name of a field or boolean option in
+// typed_options, if it differs.
+func typedFieldName(name string) string {
+	if attributeName := serviceoptions.AttributeName(name); attributeName != name {
+		return fmt.Sprintf(" / `%s`", attributeName)
+	}
+	return ""
+}
+
+func typeLabel(p serviceoptions.Property) string {
+	label := map[serviceoptions.ValueType]string{
+		serviceoptions.ValueTypeBoolean:    "Boolean",
+		serviceoptions.ValueTypeString:     "String",
+		serviceoptions.ValueTypeInteger:    "Number",
+		serviceoptions.ValueTypeNumber:     "Number",
+		serviceoptions.ValueTypeStringList: "List of String",
+		serviceoptions.ValueTypeObject:     "Object",
+		serviceoptions.ValueTypeAny:        "Dynamic",
+	}[p.Type]
+	if p.Sensitive {
+		label += ", Sensitive"
+	}
+	return label
+}
+
+func constraints(p serviceoptions.Property, requirement string) string {
+	var parts []string
+	if requirement != "" {
+		parts = append(parts, requirement)
+	}
+	if len(p.Enum) > 0 {
+		parts = append(parts, "one of: "+strings.Join(p.Enum, ", "))
+	}
+	if p.Type == serviceoptions.ValueTypeInteger {
+		parts = append(parts, "whole number")
+	}
+	if p.Min != nil {
+		parts = append(parts, "at least "+strconv.FormatFloat(*p.Min, 'g', -1, 64))
+	}
+	if p.Max != nil {
+		parts = append(parts, "at most "+strconv.FormatFloat(*p.Max, 'g', -1, 64))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return " — " + strings.Join(parts, "; ")
+}
+
+// replaceDocsSection replaces the text between the generated options markers
+// in filename with section, leaving the hand-written parts untouched.
+func replaceDocsSection(filename, section string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+
+	begin := strings.Index(content, docsBeginMarker)
+	end := strings.Index(content, docsEndMarker)
+	if begin < 0 || end < begin {
+		return fmt.Errorf("%s: could not find the %q and %q markers", filename, docsBeginMarker, docsEndMarker)
+	}
+
+	content = content[:begin] + section + content[end+len(docsEndMarker):]
+	return os.WriteFile(filename, []byte(content), 0o644)
+}
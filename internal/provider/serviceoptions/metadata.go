@@ -0,0 +1,159 @@
+// Package serviceoptions describes the CacheFly service options: their
+// names, value types and constraints. The built-in catalog is generated from
+// metadata.json, which is maintained by hand in the format of the service
+// options metadata endpoint and may lag behind the API; see doc.go.
+package serviceoptions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// OptionType is the shape an option takes in the options map.
+type OptionType string
+
+const (
+	// OptionTypeBoolean options are set directly, e.g. `allowretry = true`.
+	OptionTypeBoolean OptionType = "boolean"
+
+	// OptionTypeStandard options use the enabled/value structure, e.g.
+	// `ttfb_timeout = { enabled = true, value = 5 }`.
+	OptionTypeStandard OptionType = "standard"
+
+	// OptionTypeObject options hold an enabled flag next to named fields,
+	// e.g. `reverseProxy = { enabled = true, hostname = "..." }`.
+	OptionTypeObject OptionType = "object"
+)
+
+// ValueType is the type of an option value or field.
+type ValueType string
+
+const (
+	ValueTypeBoolean    ValueType = "boolean"
+	ValueTypeString     ValueType = "string"
+	ValueTypeInteger    ValueType = "integer"
+	ValueTypeNumber     ValueType = "number"
+	ValueTypeStringList ValueType = "string_list"
+	ValueTypeObject     ValueType = "object"
+	ValueTypeAny        ValueType = "any"
+)
+
+// Metadata is the document returned by the service options metadata
+// endpoint.
+type Metadata struct {
+	Options []Option `json:"options"`
+}
+
+// Option describes one service option.
+type Option struct {
+	Name        string     `json:"name"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Type        OptionType `json:"type"`
+
+	// Value describes the value of standard options.
+	Value *Property `json:"value,omitempty"`
+
+	// Fields describes the fields of object options.
+	Fields []Field `json:"fields,omitempty"`
+
+	// Default is the value the option has on a new service, if known.
+	Default interface{} `json:"default,omitempty"`
+
+	// ConfigurationModes lists the service configuration modes the option
+	// is available in. The option is available in every mode if it is empty.
+	ConfigurationModes []string `json:"configurationModes,omitempty"`
+}
+
+// Property constrains a value.
+type Property struct {
+	Type      ValueType `json:"type"`
+	Enum      []string  `json:"enum,omitempty"`
+	Min       *float64  `json:"min,omitempty"`
+	Max       *float64  `json:"max,omitempty"`
+	Sensitive bool      `json:"sensitive,omitempty"`
+}
+
+// Field is a named field of an object option.
+type Field struct {
+	Property
+
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Required fields must be set when the option is enabled.
+	Required bool `json:"required,omitempty"`
+
+	// RequiredWhen makes the field required when another field of the
+	// option has the given value.
+	RequiredWhen *Condition `json:"requiredWhen,omitempty"`
+}
+
+// Condition matches the value of a sibling field.
+type Condition struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// ParseMetadata decodes a metadata document into a catalog. A document
+// without options is an error: every service has options, so an empty list
+// means the response was not a metadata document, and treating it as an
+// empty catalog would reject every option.
+func ParseMetadata(data []byte) (Catalog, error) {
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("could not decode service options metadata: %w", err)
+	}
+	if len(metadata.Options) == 0 {
+		return nil, fmt.Errorf("service options metadata lists no options")
+	}
+
+	catalog := make(Catalog, len(metadata.Options))
+	for _, option := range metadata.Options {
+		if option.Name == "" {
+			return nil, fmt.Errorf("service options metadata contains an option without a name")
+		}
+		switch option.Type {
+		case OptionTypeBoolean, OptionTypeStandard, OptionTypeObject:
+		default:
+			return nil, fmt.Errorf("option %s has unsupported type %q", option.Name, option.Type)
+		}
+		catalog[option.Name] = option
+	}
+
+	return catalog, nil
+}
+
+// Catalog maps option names to their descriptions.
+type Catalog map[string]Option
+
+// Names returns the option names in alphabetical order.
+func (c Catalog) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var builtin = sync.OnceValue(func() Catalog {
+	catalog := make(Catalog, len(builtinOptions))
+	for _, option := range builtinOptions {
+		catalog[option.Name] = option
+	}
+	return catalog
+})
+
+// Builtin returns the catalog generated from the bundled metadata.json. It
+// has no defaults or configuration modes, and may miss options or fields the
+// API supports. The catalog is shared and must not be modified.
+func Builtin() Catalog {
+	return builtin()
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
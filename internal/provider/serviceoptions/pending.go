@@ -0,0 +1,69 @@
+package serviceoptions
+
+import "sort"
+
+// Pending returns, in alphabetical order, the options in applied whose value
+// the API does not report in current yet. applied holds the values the API
+// accepted for a change, so values the API normalizes compare as it stores
+// them. Options and object fields missing from current are skipped, as are
+// fields the catalog marks as sensitive: write-only values are not returned
+// by the API, so waiting for them would never end.
+func (c Catalog) Pending(applied, current map[string]interface{}) []string {
+	var pending []string
+	for name, want := range applied {
+		got, ok := current[name]
+		if !ok {
+			continue
+		}
+
+		sensitive := c.sensitiveFields(name)
+		if !equalJSON(withoutSkippedFields(want, got, sensitive), withoutSkippedFields(got, want, sensitive)) {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// sensitiveFields returns the names of the sensitive fields of an option.
+func (c Catalog) sensitiveFields(name string) map[string]bool {
+	option, ok := c[name]
+	if !ok {
+		return nil
+	}
+
+	var sensitive map[string]bool
+	for _, field := range option.Fields {
+		if field.Sensitive {
+			if sensitive == nil {
+				sensitive = make(map[string]bool)
+			}
+			sensitive[field.Name] = true
+		}
+	}
+	return sensitive
+}
+
+// withoutSkippedFields returns value without the sensitive fields and
+// without the fields that other lacks, at any depth. Values that are not
+// objects are returned unchanged.
+func withoutSkippedFields(value, other interface{}, sensitive map[string]bool) interface{} {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	otherFields, ok := other.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	filtered := make(map[string]interface{}, len(fields))
+	for key, fieldValue := range fields {
+		otherValue, ok := otherFields[key]
+		if !ok || sensitive[key] {
+			continue
+		}
+		filtered[key] = withoutSkippedFields(fieldValue, otherValue, nil)
+	}
+	return filtered
+}
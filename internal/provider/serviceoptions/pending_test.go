@@ -0,0 +1,52 @@
+package serviceoptions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestCatalog_Pending(t *testing.T) {
+	catalog := serviceoptions.Builtin()
+
+	applied := map[string]interface{}{
+		"autoRedirect": true,
+		"error_ttl":    map[string]interface{}{"enabled": true, "value": 60},
+		"reverseProxy": map[string]interface{}{
+			"enabled":   true,
+			"mode":      "OBJECT_STORAGE",
+			"secretKey": "secret",
+			"hostname":  "origin.example.com",
+		},
+		"cors": true,
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		current := map[string]interface{}{
+			"autoRedirect": true,
+			"error_ttl":    map[string]interface{}{"enabled": true, "value": float64(60)},
+			// The secret key is write-only and the hostname is not echoed.
+			"reverseProxy": map[string]interface{}{"enabled": true, "mode": "OBJECT_STORAGE", "ttl": float64(86400)},
+			// cors is not reported at all.
+		}
+		assert.Empty(t, catalog.Pending(applied, current))
+	})
+
+	t.Run("masked secret", func(t *testing.T) {
+		current := map[string]interface{}{
+			"reverseProxy": map[string]interface{}{"enabled": true, "mode": "OBJECT_STORAGE", "secretKey": "********"},
+		}
+		assert.Empty(t, catalog.Pending(applied, current))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		current := map[string]interface{}{
+			"autoRedirect": false,
+			"error_ttl":    map[string]interface{}{"enabled": true, "value": float64(30)},
+			"reverseProxy": map[string]interface{}{"enabled": true, "mode": "WEB"},
+		}
+		assert.Equal(t, []string{"autoRedirect", "error_ttl", "reverseProxy"}, catalog.Pending(applied, current))
+	})
+}
@@ -0,0 +1,324 @@
+package serviceoptions
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AttributeName returns the name of the typed_options attribute for an
+// option or field. Terraform attribute names are lowercase, so camel case
+// names and hyphens are turned into snake case, e.g. reverseProxy into
+// reverse_proxy.
+func AttributeName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '-':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 {
+				prev := rune(name[i-1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ResourceAttributes returns one optional attribute per option, for the
+// typed_options attribute of cachefly_service. Boolean options are booleans,
+// standard options are objects with enabled and value, and object options
+// are objects with their fields. Values the catalog does not describe
+// further, such as objects without listed fields, are dynamic. Options and
+// fields are named by AttributeName.
+func (c Catalog) ResourceAttributes() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(c))
+	for _, option := range c {
+		name := AttributeName(option.Name)
+		description := option.Description
+		if description == "" {
+			description = option.Title
+		}
+
+		switch option.Type {
+		case OptionTypeBoolean:
+			attributes[name] = schema.BoolAttribute{
+				MarkdownDescription: description,
+				Optional:            true,
+			}
+		case OptionTypeStandard:
+			value := Property{Type: ValueTypeAny}
+			if option.Value != nil {
+				value = *option.Value
+			}
+			attributes[name] = schema.SingleNestedAttribute{
+				MarkdownDescription: description,
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether the option is enabled.",
+						Optional:    true,
+					},
+					"value": propertyAttribute(value, "The option value."),
+				},
+			}
+		case OptionTypeObject:
+			fields := map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Description: "Whether the option is enabled.",
+					Optional:    true,
+				},
+			}
+			for _, field := range option.Fields {
+				fields[AttributeName(field.Name)] = propertyAttribute(field.Property, field.Description)
+			}
+			attributes[name] = schema.SingleNestedAttribute{
+				MarkdownDescription: description,
+				Optional:            true,
+				Attributes:          fields,
+			}
+		}
+	}
+	return attributes
+}
+
+// AttributeTypes returns the types of the attributes from ResourceAttributes.
+func (c Catalog) AttributeTypes() map[string]attr.Type {
+	attributes := c.ResourceAttributes()
+	attrTypes := make(map[string]attr.Type, len(attributes))
+	for name, attribute := range attributes {
+		attrTypes[name] = attribute.GetType()
+	}
+	return attrTypes
+}
+
+func propertyAttribute(property Property, description string) schema.Attribute {
+	switch property.Type {
+	case ValueTypeBoolean:
+		return schema.BoolAttribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive}
+	case ValueTypeString:
+		return schema.StringAttribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive}
+	case ValueTypeInteger:
+		return schema.Int64Attribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive}
+	case ValueTypeNumber:
+		return schema.Float64Attribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive}
+	case ValueTypeStringList:
+		return schema.ListAttribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive, ElementType: types.StringType}
+	default:
+		return schema.DynamicAttribute{MarkdownDescription: description, Optional: true, Sensitive: property.Sensitive}
+	}
+}
+
+// ObjectValue converts option values, as returned by the API, into a value
+// of the types from AttributeTypes. Options and fields left out are null.
+// Options or fields missing from the catalog, and values of the wrong type,
+// are an error.
+func (c Catalog) ObjectValue(options map[string]interface{}) (types.Object, error) {
+	attrTypes := c.AttributeTypes()
+
+	renamed := make(map[string]interface{}, len(options))
+	for name, value := range options {
+		option, ok := c[name]
+		if !ok {
+			return types.ObjectNull(attrTypes), fmt.Errorf("option %s is not in the options catalog", name)
+		}
+		if fields, ok := value.(map[string]interface{}); ok && option.Type == OptionTypeObject {
+			renamedFields := make(map[string]interface{}, len(fields))
+			for field, fieldValue := range fields {
+				renamedFields[AttributeName(field)] = fieldValue
+			}
+			value = renamedFields
+		}
+		renamed[AttributeName(name)] = value
+	}
+
+	value, err := typedValue(types.ObjectType{AttrTypes: attrTypes}, renamed)
+	if err != nil {
+		return types.ObjectNull(attrTypes), err
+	}
+	return value.(types.Object), nil
+}
+
+// FromObject converts a typed_options value into option values named as the
+// API names them, the inverse of ObjectValue. Null options and fields are
+// left out, and unknown values are kept as FromValue marks them. It returns
+// nil if value is null or unknown.
+func (c Catalog) FromObject(value types.Object) map[string]interface{} {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	attributes := value.Attributes()
+	options := make(map[string]interface{})
+	for name, option := range c {
+		converted := FromValue(attributes[AttributeName(name)])
+		if converted == nil {
+			continue
+		}
+
+		if fields, ok := converted.(map[string]interface{}); ok && option.Type == OptionTypeObject {
+			renamed := make(map[string]interface{}, len(fields))
+			if enabled, ok := fields["enabled"]; ok {
+				renamed["enabled"] = enabled
+			}
+			for _, field := range option.Fields {
+				if fieldValue, ok := fields[AttributeName(field.Name)]; ok {
+					renamed[field.Name] = fieldValue
+				}
+			}
+			converted = renamed
+		}
+		options[name] = converted
+	}
+	return options
+}
+
+// typedValue converts a value into a value of attrType.
+func typedValue(attrType attr.Type, value interface{}) (attr.Value, error) {
+	switch t := attrType.(type) {
+	case types.ObjectType:
+		if value == nil {
+			return types.ObjectNull(t.AttrTypes), nil
+		}
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", value)
+		}
+		for name := range fields {
+			if _, ok := t.AttrTypes[name]; !ok {
+				return nil, fmt.Errorf("field %s is not in the options catalog", name)
+			}
+		}
+
+		attributes := make(map[string]attr.Value, len(t.AttrTypes))
+		for name, fieldType := range t.AttrTypes {
+			fieldValue, err := typedValue(fieldType, fields[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			attributes[name] = fieldValue
+		}
+		object, diags := types.ObjectValue(t.AttrTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("could not build object: %v", diags.Errors())
+		}
+		return object, nil
+
+	case types.ListType:
+		if value == nil {
+			return types.ListNull(t.ElemType), nil
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a list, got %T", value)
+		}
+		elements := make([]attr.Value, len(items))
+		for i, item := range items {
+			element, err := typedValue(t.ElemType, item)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			elements[i] = element
+		}
+		list, diags := types.ListValue(t.ElemType, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("could not build list: %v", diags.Errors())
+		}
+		return list, nil
+	}
+
+	switch attrType {
+	case types.BoolType:
+		if value == nil {
+			return types.BoolNull(), nil
+		}
+		if v, ok := value.(bool); ok {
+			return types.BoolValue(v), nil
+		}
+	case types.StringType:
+		if value == nil {
+			return types.StringNull(), nil
+		}
+		if v, ok := value.(string); ok {
+			return types.StringValue(v), nil
+		}
+	case types.Int64Type:
+		switch v := value.(type) {
+		case nil:
+			return types.Int64Null(), nil
+		case int:
+			return types.Int64Value(int64(v)), nil
+		case int64:
+			return types.Int64Value(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return types.Int64Value(int64(v)), nil
+			}
+		}
+	case types.Float64Type:
+		switch v := value.(type) {
+		case nil:
+			return types.Float64Null(), nil
+		case int:
+			return types.Float64Value(float64(v)), nil
+		case float64:
+			return types.Float64Value(v), nil
+		}
+	case types.DynamicType:
+		if value == nil {
+			return types.DynamicNull(), nil
+		}
+		return types.DynamicValue(dynamicValue(value)), nil
+	}
+
+	return nil, fmt.Errorf("expected a value of type %s, got %T", attrType, value)
+}
+
+// dynamicValue converts a value of an untyped option or field. Objects become
+// objects and lists become tuples, so that their elements keep their types.
+func dynamicValue(value interface{}) attr.Value {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case int:
+		return types.Int64Value(int64(v))
+	case int64:
+		return types.Int64Value(v)
+	case float64:
+		return types.Float64Value(v)
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for key, field := range v {
+			attributes[key] = dynamicValue(field)
+			attrTypes[key] = attributes[key].Type(context.Background())
+		}
+		object, _ := types.ObjectValue(attrTypes, attributes)
+		return object
+	case []interface{}:
+		elemTypes := make([]attr.Type, len(v))
+		elements := make([]attr.Value, len(v))
+		for i, item := range v {
+			elements[i] = dynamicValue(item)
+			elemTypes[i] = elements[i].Type(context.Background())
+		}
+		tuple, _ := types.TupleValue(elemTypes, elements)
+		return tuple
+	default:
+		return types.StringValue(fmt.Sprintf("%v", v))
+	}
+}
@@ -0,0 +1,115 @@
+package serviceoptions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestAttributeName(t *testing.T) {
+	tests := map[string]string{
+		"cors":                   "cors",
+		"error_ttl":              "error_ttl",
+		"reverseProxy":           "reverse_proxy",
+		"protectServeKeyEnabled": "protect_serve_key_enabled",
+		"send-xff":               "send_xff",
+		"ttl2Seconds":            "ttl2_seconds",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, serviceoptions.AttributeName(name), name)
+	}
+}
+
+func TestCatalog_ResourceAttributes(t *testing.T) {
+	catalog := serviceoptions.Builtin()
+	valid := regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+	attributes := catalog.ResourceAttributes()
+	// Every option has its own attribute, so no two names collide.
+	assert.Len(t, attributes, len(catalog))
+
+	for name, attribute := range attributes {
+		assert.Regexp(t, valid, name)
+		if nested, ok := attribute.(schema.SingleNestedAttribute); ok {
+			for field := range nested.Attributes {
+				assert.Regexp(t, valid, field, name)
+			}
+		}
+	}
+
+	reverseProxy, ok := attributes["reverse_proxy"].(schema.SingleNestedAttribute)
+	if assert.True(t, ok) {
+		assert.IsType(t, schema.StringAttribute{}, reverseProxy.Attributes["origin_scheme"])
+		assert.True(t, reverseProxy.Attributes["secret_key"].IsSensitive())
+	}
+	assert.IsType(t, schema.BoolAttribute{}, attributes["cors"])
+	if errorTTL, ok := attributes["error_ttl"].(schema.SingleNestedAttribute); assert.True(t, ok) {
+		assert.IsType(t, schema.Int64Attribute{}, errorTTL.Attributes["value"])
+	}
+}
+
+func TestCatalog_ObjectValue(t *testing.T) {
+	catalog := serviceoptions.Builtin()
+
+	options := map[string]interface{}{
+		"cors":      true,
+		"error_ttl": map[string]interface{}{"enabled": true, "value": float64(60)},
+		"reverseProxy": map[string]interface{}{
+			"enabled":      true,
+			"hostname":     "origin.example.com",
+			"originScheme": "HTTPS",
+			"ttl":          float64(86400),
+		},
+	}
+
+	value, err := catalog.ObjectValue(options)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	attributes := value.Attributes()
+	assert.Equal(t, types.BoolValue(true), attributes["cors"])
+	assert.True(t, attributes["send_xff"].IsNull())
+
+	reverseProxy := attributes["reverse_proxy"].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("HTTPS"), reverseProxy["origin_scheme"])
+	assert.Equal(t, types.Int64Value(86400), reverseProxy["ttl"])
+	assert.True(t, reverseProxy["secret_key"].IsNull())
+
+	// Converting back gives the API names, without the null options and
+	// fields.
+	assert.Equal(t, map[string]interface{}{
+		"cors":      true,
+		"error_ttl": map[string]interface{}{"enabled": true, "value": 60},
+		"reverseProxy": map[string]interface{}{
+			"enabled":      true,
+			"hostname":     "origin.example.com",
+			"originScheme": "HTTPS",
+			"ttl":          86400,
+		},
+	}, catalog.FromObject(value))
+}
+
+func TestCatalog_ObjectValue_Errors(t *testing.T) {
+	catalog := serviceoptions.Builtin()
+
+	_, err := catalog.ObjectValue(map[string]interface{}{"notAnOption": true})
+	assert.ErrorContains(t, err, "option notAnOption is not in the options catalog")
+
+	_, err = catalog.ObjectValue(map[string]interface{}{"cors": "yes"})
+	assert.ErrorContains(t, err, "cors")
+
+	_, err = catalog.ObjectValue(map[string]interface{}{"reverseProxy": map[string]interface{}{"enabled": true, "unknownField": 1}})
+	assert.ErrorContains(t, err, "field unknown_field is not in the options catalog")
+}
+
+func TestCatalog_FromObject_Null(t *testing.T) {
+	catalog := serviceoptions.Builtin()
+	assert.Nil(t, catalog.FromObject(types.ObjectNull(catalog.AttributeTypes())))
+	assert.Nil(t, catalog.FromObject(types.ObjectUnknown(catalog.AttributeTypes())))
+}
@@ -0,0 +1,350 @@
+package serviceoptions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ProblemKind classifies a validation problem.
+type ProblemKind int
+
+const (
+	// ProblemInvalidValue is a value of the wrong type or out of range.
+	ProblemInvalidValue ProblemKind = iota
+
+	// ProblemUnknownOption is an option that is not in the catalog.
+	ProblemUnknownOption
+
+	// ProblemUnsupportedField is a field the catalog does not list for the
+	// option. The built-in catalog may lag behind the API, so callers
+	// validating against it should not treat this as fatal.
+	ProblemUnsupportedField
+
+	// ProblemMissingField is a required field that is not set.
+	ProblemMissingField
+
+	// ProblemConfigurationMode is an option that is not available in the
+	// service's configuration mode.
+	ProblemConfigurationMode
+)
+
+// Problem is a validation failure for one option, or for one field of it.
+type Problem struct {
+	Option string
+
+	// Field is the field of an object option, or "value" for standard
+	// options. It is empty when the problem concerns the option itself.
+	Field string
+
+	Kind    ProblemKind
+	Message string
+}
+
+// ValidateOptions controls how strictly options are checked.
+type ValidateOptions struct {
+	// RejectUnknown reports options that are not in the catalog. Leave it
+	// unset when the catalog may be incomplete, as the built-in one is.
+	RejectUnknown bool
+
+	// ConfigurationMode reports options that are not available in the given
+	// service configuration mode. Leave it empty to skip the check.
+	ConfigurationMode string
+}
+
+// Validate checks options, as produced by FromValue, against the catalog and
+// returns the problems sorted by option and field.
+func (c Catalog) Validate(options map[string]interface{}, opts ValidateOptions) []Problem {
+	var problems []Problem
+
+	for name, value := range options {
+		if value == nil || IsUnknown(value) {
+			continue
+		}
+
+		option, ok := c[name]
+		if !ok {
+			if opts.RejectUnknown {
+				problems = append(problems, Problem{Option: name, Kind: ProblemUnknownOption, Message: c.unknownOptionMessage(name)})
+			}
+			continue
+		}
+
+		if opts.ConfigurationMode != "" && len(option.ConfigurationModes) > 0 && !contains(option.ConfigurationModes, opts.ConfigurationMode) {
+			problems = append(problems, Problem{
+				Option: name,
+				Kind:   ProblemConfigurationMode,
+				Message: fmt.Sprintf("%s is not available in configuration mode %s, only in %s.",
+					name, opts.ConfigurationMode, strings.Join(option.ConfigurationModes, ", ")),
+			})
+			continue
+		}
+
+		problems = append(problems, option.validate(value)...)
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Option != problems[j].Option {
+			return problems[i].Option < problems[j].Option
+		}
+		return problems[i].Field < problems[j].Field
+	})
+
+	return problems
+}
+
+func (c Catalog) unknownOptionMessage(name string) string {
+	message := fmt.Sprintf("%q is not a service option supported for this service.", name)
+	if suggestion := c.closest(name); suggestion != "" {
+		message += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	return message
+}
+
+// closest returns the catalog option whose name is most similar to name, if
+// any is close enough to be a likely typo.
+func (c Catalog) closest(name string) string {
+	best, bestDistance := "", math.MaxInt
+	for _, candidate := range c.Names() {
+		if strings.EqualFold(candidate, name) {
+			return candidate
+		}
+		if d := levenshtein(strings.ToLower(candidate), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	if bestDistance <= 2 || bestDistance <= len(name)/4 {
+		return best
+	}
+	return ""
+}
+
+func (o Option) validate(value interface{}) []Problem {
+	switch o.Type {
+	case OptionTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return []Problem{{Option: o.Name, Message: fmt.Sprintf("Expected a boolean, got %s.", describe(value))}}
+		}
+		return nil
+
+	case OptionTypeStandard:
+		return o.validateStandard(value)
+
+	case OptionTypeObject:
+		return o.validateObject(value)
+	}
+
+	return nil
+}
+
+func (o Option) validateStandard(value interface{}) []Problem {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return []Problem{{Option: o.Name, Message: fmt.Sprintf("Expected an object with enabled and value, got %s.", describe(value))}}
+	}
+
+	var problems []Problem
+	for key, fieldValue := range fields {
+		switch key {
+		case "enabled":
+			if message := checkProperty(Property{Type: ValueTypeBoolean}, fieldValue); message != "" {
+				problems = append(problems, Problem{Option: o.Name, Field: key, Message: message})
+			}
+		case "value":
+			if o.Value == nil {
+				continue
+			}
+			if message := checkProperty(*o.Value, fieldValue); message != "" {
+				problems = append(problems, Problem{Option: o.Name, Field: key, Message: message})
+			}
+		default:
+			problems = append(problems, Problem{Option: o.Name, Field: key, Kind: ProblemUnsupportedField, Message: fmt.Sprintf("Unsupported field %q, %s only accepts enabled and value.", key, o.Name)})
+		}
+	}
+
+	if isEnabled(fields) && o.Value != nil {
+		if _, ok := fields["value"]; !ok {
+			problems = append(problems, Problem{Option: o.Name, Field: "value", Kind: ProblemMissingField, Message: "value is required when the option is enabled."})
+		}
+	}
+
+	return problems
+}
+
+func (o Option) validateObject(value interface{}) []Problem {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return []Problem{{Option: o.Name, Message: fmt.Sprintf("Expected an object, got %s.", describe(value))}}
+	}
+
+	known := make(map[string]Field, len(o.Fields))
+	for _, field := range o.Fields {
+		known[field.Name] = field
+	}
+
+	var problems []Problem
+	for key, fieldValue := range fields {
+		field, ok := known[key]
+		if !ok {
+			problems = append(problems, Problem{Option: o.Name, Field: key, Kind: ProblemUnsupportedField, Message: fmt.Sprintf("Unsupported field %q for %s.", key, o.Name)})
+			continue
+		}
+		if message := checkProperty(field.Property, fieldValue); message != "" {
+			problems = append(problems, Problem{Option: o.Name, Field: key, Message: message})
+		}
+	}
+
+	if !isEnabled(fields) {
+		return problems
+	}
+
+	for _, field := range o.Fields {
+		if _, ok := fields[field.Name]; ok {
+			continue
+		}
+
+		switch {
+		case field.Required:
+			problems = append(problems, Problem{Option: o.Name, Field: field.Name, Kind: ProblemMissingField, Message: fmt.Sprintf("%s is required when %s is enabled.", field.Name, o.Name)})
+		case field.RequiredWhen != nil:
+			if actual, ok := fields[field.RequiredWhen.Field].(string); ok && actual == field.RequiredWhen.Value {
+				problems = append(problems, Problem{
+					Option:  o.Name,
+					Field:   field.Name,
+					Kind:    ProblemMissingField,
+					Message: fmt.Sprintf("%s is required when %s is %q.", field.Name, field.RequiredWhen.Field, field.RequiredWhen.Value),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// isEnabled reports whether an enabled/value or object option is switched
+// on. Options without an enabled field, or whose enabled field is not known
+// yet, are treated as enabled.
+func isEnabled(fields map[string]interface{}) bool {
+	enabled, ok := fields["enabled"].(bool)
+	return !ok || enabled
+}
+
+// checkProperty returns a message describing why value does not satisfy p,
+// or an empty string.
+func checkProperty(p Property, value interface{}) string {
+	if value == nil || IsUnknown(value) {
+		return ""
+	}
+
+	switch p.Type {
+	case ValueTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("Expected a boolean, got %s.", describe(value))
+		}
+
+	case ValueTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("Expected a string, got %s.", describe(value))
+		}
+		if len(p.Enum) > 0 && !contains(p.Enum, s) {
+			return fmt.Sprintf("Expected one of %s, got %q.", strings.Join(p.Enum, ", "), s)
+		}
+
+	case ValueTypeInteger, ValueTypeNumber:
+		n, ok := toFloat(value)
+		if !ok {
+			return fmt.Sprintf("Expected a number, got %s.", describe(value))
+		}
+		if p.Type == ValueTypeInteger && n != math.Trunc(n) {
+			return fmt.Sprintf("Expected a whole number, got %v.", n)
+		}
+		if p.Min != nil && n < *p.Min {
+			return fmt.Sprintf("Expected a value of at least %v, got %v.", *p.Min, n)
+		}
+		if p.Max != nil && n > *p.Max {
+			return fmt.Sprintf("Expected a value of at most %v, got %v.", *p.Max, n)
+		}
+
+	case ValueTypeStringList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Sprintf("Expected a list of strings, got %s.", describe(value))
+		}
+		for i, item := range items {
+			if _, ok := item.(string); !ok && item != nil && !IsUnknown(item) {
+				return fmt.Sprintf("Expected a list of strings, element %d is %s.", i, describe(item))
+			}
+		}
+
+	case ValueTypeObject:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Sprintf("Expected an object, got %s.", describe(value))
+		}
+	}
+
+	return ""
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return fmt.Sprintf("the boolean %v", v)
+	case string:
+		return fmt.Sprintf("the string %q", v)
+	case int, int64, float64:
+		return fmt.Sprintf("the number %v", v)
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
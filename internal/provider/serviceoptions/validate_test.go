@@ -0,0 +1,185 @@
+package serviceoptions_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/serviceoptions"
+)
+
+func TestBuiltin_MatchesMetadata(t *testing.T) {
+	data, err := os.ReadFile("metadata.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	catalog, err := serviceoptions.ParseMetadata(data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, catalog, serviceoptions.Builtin(), "catalog_gen.go is out of date, run `make generate`")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	reverseProxy := func(overrides map[string]interface{}) map[string]interface{} {
+		value := map[string]interface{}{
+			"enabled":           true,
+			"hostname":          "origin.example.com",
+			"originScheme":      "HTTPS",
+			"ttl":               3600,
+			"useRobotsTxt":      false,
+			"cacheByQueryParam": true,
+		}
+		for k, v := range overrides {
+			value[k] = v
+		}
+		return value
+	}
+
+	testCases := []struct {
+		name     string
+		options  map[string]interface{}
+		opts     serviceoptions.ValidateOptions
+		problems []serviceoptions.Problem
+	}{
+		{
+			name: "valid",
+			options: map[string]interface{}{
+				"autoRedirect": true,
+				"ttfb_timeout": map[string]interface{}{"enabled": true, "value": 5},
+				"purgemode":    map[string]interface{}{"enabled": true, "value": "2"},
+				"reverseProxy": reverseProxy(nil),
+			},
+		},
+		{
+			name:    "boolean option with wrong type",
+			options: map[string]interface{}{"cors": "yes"},
+			problems: []serviceoptions.Problem{
+				{Option: "cors", Message: `Expected a boolean, got the string "yes".`},
+			},
+		},
+		{
+			name:    "value below minimum",
+			options: map[string]interface{}{"contimeout": map[string]interface{}{"enabled": true, "value": 0}},
+			problems: []serviceoptions.Problem{
+				{Option: "contimeout", Field: "value", Message: "Expected a value of at least 1, got 0."},
+			},
+		},
+		{
+			name:    "missing value",
+			options: map[string]interface{}{"redirect": map[string]interface{}{"enabled": true}},
+			problems: []serviceoptions.Problem{
+				{Option: "redirect", Field: "value", Kind: serviceoptions.ProblemMissingField, Message: "value is required when the option is enabled."},
+			},
+		},
+		{
+			name:    "disabled option without value",
+			options: map[string]interface{}{"redirect": map[string]interface{}{"enabled": false}},
+		},
+		{
+			name:    "enum",
+			options: map[string]interface{}{"reverseProxy": reverseProxy(map[string]interface{}{"originScheme": "FTP"})},
+			problems: []serviceoptions.Problem{
+				{Option: "reverseProxy", Field: "originScheme", Message: `Expected one of FOLLOW, HTTP, HTTPS, got "FTP".`},
+			},
+		},
+		{
+			name:    "required when",
+			options: map[string]interface{}{"reverseProxy": reverseProxy(map[string]interface{}{"mode": "OBJECT_STORAGE", "accessKey": "key", "region": "us-east-1"})},
+			problems: []serviceoptions.Problem{
+				{Option: "reverseProxy", Field: "secretKey", Kind: serviceoptions.ProblemMissingField, Message: `secretKey is required when mode is "OBJECT_STORAGE".`},
+			},
+		},
+		{
+			name:    "unsupported field",
+			options: map[string]interface{}{"reverseProxy": reverseProxy(map[string]interface{}{"hostnme": "origin.example.com"})},
+			problems: []serviceoptions.Problem{
+				{Option: "reverseProxy", Field: "hostnme", Kind: serviceoptions.ProblemUnsupportedField, Message: `Unsupported field "hostnme" for reverseProxy.`},
+			},
+		},
+		{
+			name:    "unknown options are ignored by default",
+			options: map[string]interface{}{"somethingNew": true},
+		},
+		{
+			name:    "unknown options are rejected on request",
+			options: map[string]interface{}{"autoredirect": true},
+			opts:    serviceoptions.ValidateOptions{RejectUnknown: true},
+			problems: []serviceoptions.Problem{
+				{Option: "autoredirect", Kind: serviceoptions.ProblemUnknownOption, Message: `"autoredirect" is not a service option supported for this service. Did you mean "autoRedirect"?`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.problems, serviceoptions.Builtin().Validate(tc.options, tc.opts))
+		})
+	}
+}
+
+func TestValidate_UnknownValues(t *testing.T) {
+	value := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"reverseProxy": types.ObjectType{AttrTypes: map[string]attr.Type{
+				"enabled":           types.BoolType,
+				"hostname":          types.StringType,
+				"originScheme":      types.StringType,
+				"ttl":               types.NumberType,
+				"useRobotsTxt":      types.BoolType,
+				"cacheByQueryParam": types.BoolType,
+			}},
+		},
+		map[string]attr.Value{
+			"reverseProxy": types.ObjectValueMust(
+				map[string]attr.Type{
+					"enabled":           types.BoolType,
+					"hostname":          types.StringType,
+					"originScheme":      types.StringType,
+					"ttl":               types.NumberType,
+					"useRobotsTxt":      types.BoolType,
+					"cacheByQueryParam": types.BoolType,
+				},
+				map[string]attr.Value{
+					"enabled":           types.BoolValue(true),
+					"hostname":          types.StringUnknown(),
+					"originScheme":      types.StringValue("HTTPS"),
+					"ttl":               types.NumberUnknown(),
+					"useRobotsTxt":      types.BoolValue(true),
+					"cacheByQueryParam": types.BoolNull(),
+				},
+			),
+		},
+	))
+
+	options, ok := serviceoptions.MapFromValue(value)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	// Unknown fields count as set, null fields do not.
+	assert.Equal(t, []serviceoptions.Problem{
+		{Option: "reverseProxy", Field: "cacheByQueryParam", Kind: serviceoptions.ProblemMissingField, Message: "cacheByQueryParam is required when reverseProxy is enabled."},
+	}, serviceoptions.Builtin().Validate(options, serviceoptions.ValidateOptions{}))
+}
+
+func TestValidate_ConfigurationMode(t *testing.T) {
+	catalog, err := serviceoptions.ParseMetadata([]byte(`{"options": [
+		{"name": "cors", "type": "boolean"},
+		{"name": "legacyRules", "type": "boolean", "configurationModes": ["MIXED_RULES_AND_OPTIONS"]}
+	]}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	options := map[string]interface{}{"cors": true, "legacyRules": true}
+
+	assert.Empty(t, catalog.Validate(options, serviceoptions.ValidateOptions{}))
+	assert.Empty(t, catalog.Validate(options, serviceoptions.ValidateOptions{ConfigurationMode: "MIXED_RULES_AND_OPTIONS"}))
+	assert.Equal(t, []serviceoptions.Problem{
+		{Option: "legacyRules", Kind: serviceoptions.ProblemConfigurationMode, Message: "legacyRules is not available in configuration mode API_RULES_AND_OPTIONS, only in MIXED_RULES_AND_OPTIONS."},
+	}, catalog.Validate(options, serviceoptions.ValidateOptions{ConfigurationMode: "API_RULES_AND_OPTIONS"}))
+}
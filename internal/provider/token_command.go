@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTokenCommandTimeout bounds how long api_token_command may run.
+const defaultTokenCommandTimeout = 30 * time.Second
+
+// tokenCommandCache remembers the tokens returned by api_token_command for
+// the lifetime of the provider process, so the helper runs once per
+// Terraform command even when the provider is configured several times.
+var tokenCommandCache = struct {
+	sync.Mutex
+	tokens map[string]string
+}{tokens: map[string]string{}}
+
+// runTokenCommand runs argv and returns the first line it prints on stdout,
+// in the same way git and docker credential helpers work. Results are cached
+// per argv.
+func runTokenCommand(ctx context.Context, argv []string, timeout time.Duration) (string, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return "", errors.New("the command must contain at least the program to run")
+	}
+
+	key := strings.Join(argv, "\x00")
+
+	tokenCommandCache.Lock()
+	defer tokenCommandCache.Unlock()
+
+	if token, ok := tokenCommandCache.tokens[key]; ok {
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait for children of the helper that keep stdout open after it
+	// was killed.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("%s timed out after %s", argv[0], timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s failed: %w: %s", argv[0], err, message)
+		}
+		return "", fmt.Errorf("%s failed: %w", argv[0], err)
+	}
+
+	token, _, _ := strings.Cut(stdout.String(), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("%s did not print a token on stdout", argv[0])
+	}
+
+	tokenCommandCache.tokens[key] = token
+	return token, nil
+}
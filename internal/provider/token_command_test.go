@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTokenHelper writes a stub credential helper that records every call
+// in a counter file and prints token.
+func writeTokenHelper(t *testing.T, token string) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "token-helper.sh")
+
+	content := "#!/bin/sh\necho called >> " + counter + "\necho '" + token + "'\necho 'ignored second line'\n"
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	return script, counter
+}
+
+func countCalls(t *testing.T, counter string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(counter)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(string(data), "called")
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	script, counter := writeTokenHelper(t, "helper-token")
+
+	token, err := runTokenCommand(context.Background(), []string{script}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "helper-token", token)
+
+	// The second call is served from the cache.
+	token, err = runTokenCommand(context.Background(), []string{script}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "helper-token", token)
+	assert.Equal(t, 1, countCalls(t, counter))
+}
+
+func TestRunTokenCommand_Errors(t *testing.T) {
+	_, err := runTokenCommand(context.Background(), nil, time.Second)
+	assert.ErrorContains(t, err, "at least the program")
+
+	_, err = runTokenCommand(context.Background(), []string{"sh", "-c", "echo 'vault is sealed' >&2; exit 2"}, time.Second)
+	assert.ErrorContains(t, err, "vault is sealed")
+
+	_, err = runTokenCommand(context.Background(), []string{"sh", "-c", "true"}, time.Second)
+	assert.ErrorContains(t, err, "did not print a token")
+
+	_, err = runTokenCommand(context.Background(), []string{"sh", "-c", "sleep 5"}, 100*time.Millisecond)
+	assert.ErrorContains(t, err, "timed out after 100ms")
+}
+
+func TestResolveCredentials_TokenCommand(t *testing.T) {
+	t.Setenv("CACHEFLY_API_TOKEN", "env-token")
+	t.Setenv("HOME", t.TempDir())
+
+	script, _ := writeTokenHelper(t, "command-token")
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue(script)})
+
+	var diags diag.Diagnostics
+	creds := resolveCredentials(context.Background(), CacheFlyProviderModel{
+		APITokenCommand: command,
+	}, &diags)
+
+	assert.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	assert.Equal(t, "command-token", creds.APIToken)
+	assert.Equal(t, "api_token_command", creds.Source)
+
+	// api_token still wins over the command.
+	creds = resolveCredentials(context.Background(), CacheFlyProviderModel{
+		APIToken:        types.StringValue("attr-token"),
+		APITokenCommand: command,
+	}, &diags)
+	assert.Equal(t, "attr-token", creds.APIToken)
+}
+
+func TestResolveCredentials_TokenCommandFailure(t *testing.T) {
+	var diags diag.Diagnostics
+	resolveCredentials(context.Background(), CacheFlyProviderModel{
+		APITokenCommand: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("sh"), types.StringValue("-c"), types.StringValue("exit 1"),
+		}),
+	}, &diags)
+
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "API Token Command Failed", diags.Errors()[0].Summary())
+	}
+}
@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// BaseConfig holds the TLS and proxy settings of the connection to the
+// CacheFly API.
+type BaseConfig struct {
+	// CACertFile and CACertPEM add PEM encoded certificates to the system
+	// root CAs, for example the root of an inspecting corporate proxy.
+	CACertFile string
+	CACertPEM  string
+
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool
+
+	// ProxyURL sends all requests through the given proxy. When empty the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+	ProxyURL string
+
+	// The client certificate for mutual TLS, given either as files or as PEM
+	// encoded strings.
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPEM  string
+	ClientKeyPEM   string
+}
+
+// NewBaseTransport returns the transport that talks to the network, built
+// from http.DefaultTransport with the given TLS and proxy settings.
+func NewBaseTransport(config BaseConfig) (http.RoundTripper, error) {
+	base := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		base = defaultTransport.Clone()
+	}
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: expected a URL such as http://proxy.example.com:3128", config.ProxyURL)
+		}
+		base.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	rootCAs, err := rootCAs(config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.RootCAs = rootCAs
+
+	certificate, err := clientCertificate(config)
+	if err != nil {
+		return nil, err
+	}
+	if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+
+	base.TLSClientConfig = tlsConfig
+
+	return base, nil
+}
+
+// rootCAs returns the system roots with the configured CA certificates
+// appended, or nil to use the system roots unchanged.
+func rootCAs(config BaseConfig) (*x509.CertPool, error) {
+	if config.CACertFile == "" && config.CACertPEM == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if config.CACertFile != "" {
+		data, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificate file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA certificate file %s", config.CACertFile)
+		}
+	}
+
+	if config.CACertPEM != "" {
+		if !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, errors.New("no PEM encoded certificates found in CA certificate PEM")
+		}
+	}
+
+	return pool, nil
+}
+
+// clientCertificate loads the mutual TLS client certificate, or returns nil
+// when none is configured.
+func clientCertificate(config BaseConfig) (*tls.Certificate, error) {
+	certPEM := []byte(config.ClientCertPEM)
+	keyPEM := []byte(config.ClientKeyPEM)
+
+	if config.ClientCertFile != "" {
+		data, err := os.ReadFile(config.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client certificate file: %w", err)
+		}
+		certPEM = data
+	}
+
+	if config.ClientKeyFile != "" {
+		data, err := os.ReadFile(config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client key file: %w", err)
+		}
+		keyPEM = data
+	}
+
+	if len(certPEM) == 0 && len(keyPEM) == 0 {
+		return nil, nil
+	}
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, errors.New("a client certificate and a client key must be configured together")
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("could not load client certificate: %w", err)
+	}
+
+	return &certificate, nil
+}
@@ -0,0 +1,164 @@
+package transport_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/transport"
+)
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// generateClientCertificate returns a self-signed client certificate and key
+// in PEM encoding.
+func generateClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestBaseTransport_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by default.
+	base, err := transport.NewBaseTransport(transport.BaseConfig{})
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: base}).Get(server.URL)
+	assert.Error(t, err)
+
+	base, err = transport.NewBaseTransport(transport.BaseConfig{CACertPEM: serverCAPEM(server)})
+	assert.NoError(t, err)
+	resp, err := (&http.Client{Transport: base}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte(serverCAPEM(server)), 0o600))
+
+	base, err = transport.NewBaseTransport(transport.BaseConfig{CACertFile: caFile})
+	assert.NoError(t, err)
+	resp, err = (&http.Client{Transport: base}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestBaseTransport_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	base, err := transport.NewBaseTransport(transport.BaseConfig{InsecureSkipVerify: true})
+	assert.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestBaseTransport_ClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := generateClientCertificate(t)
+
+	base, err := transport.NewBaseTransport(transport.BaseConfig{
+		CACertPEM:     serverCAPEM(server),
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	})
+	assert.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestBaseTransport_Proxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	base, err := transport.NewBaseTransport(transport.BaseConfig{ProxyURL: proxy.URL})
+	assert.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get("http://api.cachefly.invalid/api/2.6/services")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+	assert.Equal(t, "http://api.cachefly.invalid/api/2.6/services", proxiedURL)
+}
+
+func TestBaseTransport_InvalidConfig(t *testing.T) {
+	_, err := transport.NewBaseTransport(transport.BaseConfig{ProxyURL: "proxy:3128"})
+	assert.ErrorContains(t, err, "invalid proxy URL")
+
+	_, err = transport.NewBaseTransport(transport.BaseConfig{CACertPEM: "not a certificate"})
+	assert.ErrorContains(t, err, "no PEM encoded certificates")
+
+	certPEM, _ := generateClientCertificate(t)
+	_, err = transport.NewBaseTransport(transport.BaseConfig{ClientCertPEM: certPEM})
+	assert.ErrorContains(t, err, "must be configured together")
+}
@@ -0,0 +1,172 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LimitConfig controls how many CacheFly API requests the provider sends at
+// once and how quickly.
+type LimitConfig struct {
+	// MaxConcurrentRequests caps the number of requests in flight. Zero
+	// means no limit.
+	MaxConcurrentRequests int
+
+	// RequestsPerSecond caps the rate at which requests are started. Zero
+	// means no limit.
+	RequestsPerSecond int
+}
+
+// limitTransport coordinates every request made through the shared client so
+// that large graphs do not hit the API in bursts.
+type limitTransport struct {
+	next http.RoundTripper
+
+	// slots is a semaphore holding one token per in-flight request.
+	slots chan struct{}
+
+	mu       sync.Mutex
+	interval time.Duration
+	nextSlot time.Time
+}
+
+// NewLimitTransport wraps next with a concurrency semaphore and an optional
+// per-second request budget. A request holds its semaphore slot until the
+// response body has been read to the end or closed. Requests that fail, and
+// responses without a body, release the slot before RoundTrip returns.
+func NewLimitTransport(next http.RoundTripper, config LimitConfig) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &limitTransport{next: next}
+
+	if config.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	if config.RequestsPerSecond > 0 {
+		t.interval = time.Second / time.Duration(config.RequestsPerSecond)
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.acquire(ctx); err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	if err := t.wait(ctx); err != nil {
+		t.release()
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil || resp.Body == http.NoBody {
+		t.release()
+		return resp, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *limitTransport) acquire(ctx context.Context) error {
+	if t.slots == nil {
+		return nil
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	tflog.Trace(ctx, "Waiting for a free CacheFly API request slot", map[string]interface{}{
+		"max_concurrent_requests": cap(t.slots),
+	})
+
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *limitTransport) release() {
+	if t.slots == nil {
+		return
+	}
+	<-t.slots
+}
+
+// wait blocks until the request fits into the per-second budget.
+func (t *limitTransport) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	if t.nextSlot.Before(now) {
+		t.nextSlot = now
+	}
+	delay := t.nextSlot.Sub(now)
+	t.nextSlot = t.nextSlot.Add(t.interval)
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody frees the request's semaphore slot once the caller is done
+// with the response: when the body has been read to the end or closed,
+// whichever happens first.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.release)
+	}
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
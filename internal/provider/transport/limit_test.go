@@ -0,0 +1,159 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/transport"
+)
+
+func TestLimitTransport_CapsConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&peak)
+			if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{
+		MaxConcurrentRequests: 2,
+	})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL + "/services")
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestLimitTransport_EnforcesRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{
+		RequestsPerSecond: 10,
+	})}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(server.URL + "/services")
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+
+	// The first request goes out immediately, the remaining three are spaced 100ms apart.
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestLimitTransport_UnlimitedByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{})}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(server.URL + "/services")
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestLimitTransport_ReleasesSlotWhenBodyIsReadWithoutClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{
+		MaxConcurrentRequests: 1,
+	})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/services", nil)
+		resp, err := client.Do(req)
+		if !assert.NoError(t, err, "request %d should get a slot", i) {
+			return
+		}
+		// The body is read to the end but deliberately never closed.
+		_, err = io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+	}
+}
+
+func TestLimitTransport_ReleasesSlotOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{
+		MaxConcurrentRequests: 1,
+	})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url+"/services", nil)
+		_, err := client.Do(req)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded, "request %d should not wait for a slot", i)
+	}
+}
+
+func TestLimitTransport_ReleasesSlotForResponseWithoutBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewLimitTransport(http.DefaultTransport, transport.LimitConfig{
+		MaxConcurrentRequests: 1,
+	})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodHead, server.URL+"/services", nil)
+		// The response is deliberately never read or closed.
+		_, err := client.Do(req)
+		assert.NoError(t, err, "request %d should get a slot", i)
+	}
+}
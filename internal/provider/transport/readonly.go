@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+// readOnlyTransport rejects every request that could change CacheFly objects.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// NewReadOnlyTransport wraps next so that only GET, HEAD and OPTIONS requests
+// are sent. Other requests fail with an error wrapping apierrors.ErrReadOnly
+// without reaching the network.
+func NewReadOnlyTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &readOnlyTransport{next: next}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	tflog.Debug(req.Context(), "Blocked CacheFly API request in read-only mode", map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	})
+
+	return nil, fmt.Errorf("%w: refusing to send %s %s", apierrors.ErrReadOnly, req.Method, req.URL.Path)
+}
@@ -0,0 +1,43 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/transport"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: transport.NewReadOnlyTransport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/services")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL+"/services/123", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+
+		_, err = client.Do(req)
+		assert.True(t, errors.Is(err, apierrors.ErrReadOnly), "%s should be blocked, got %v", method, err)
+		assert.True(t, apierrors.IsKind(err, apierrors.KindReadOnly))
+	}
+
+	// Only the GET request reached the server.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,214 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+// RetryConfig controls how failed CacheFly API requests are retried.
+type RetryConfig struct {
+	// MaxRetries is the number of additional attempts made after the first
+	// one fails. Zero disables retries.
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between attempts.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryConfig returns the retry settings used when the provider
+// configuration does not override them.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: 3,
+		MinBackoff: 1 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// retryTransport retries requests that failed because of rate limiting,
+// transient gateway errors or dropped connections.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+}
+
+// NewRetryTransport wraps next so that rate-limited and transient failures are
+// retried with exponential backoff. Requests that are not idempotent are only
+// retried when the API rejected them before processing (HTTP 429).
+func NewRetryTransport(next http.RoundTripper, config RetryConfig) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		next:   next,
+		config: config,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	getBody, buffered, err := rewindableBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if getBody != nil && (attempt > 0 || buffered) {
+			attemptReq = req.Clone(ctx)
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.config.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"max":     t.config.MaxRetries,
+			"wait":    wait.String(),
+		}
+		if resp != nil {
+			fields["status"] = resp.StatusCode
+			drainAndClose(resp)
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		tflog.Debug(ctx, "Retrying CacheFly API request", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header sent by the API replaces the computed delay, whether it is shorter or
+// longer, but is still capped at MaxBackoff.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if t.config.MaxBackoff > 0 && retryAfter > t.config.MaxBackoff {
+				return t.config.MaxBackoff
+			}
+			return retryAfter
+		}
+	}
+
+	delay := t.config.MinBackoff << attempt
+	if delay <= 0 || delay > t.config.MaxBackoff {
+		delay = t.config.MaxBackoff
+	}
+
+	// Spread out clients that were throttled at the same moment.
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+
+	return delay
+}
+
+// shouldRetry reports whether a request that produced resp or err is worth
+// sending again.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isIdempotent(req.Method)
+	}
+
+	switch apierrors.KindForStatus(resp.StatusCode) {
+	case apierrors.KindRateLimited:
+		return true
+	case apierrors.KindUnavailable:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter understands both the delay-seconds and HTTP-date forms of
+// the Retry-After header.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// rewindableBody returns a function producing fresh copies of the request
+// body so it can be replayed on retries. When the request does not provide
+// GetBody the body is read into memory and buffered is true.
+func rewindableBody(req *http.Request) (getBody func() (io.ReadCloser, error), buffered bool, err error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, false, nil
+	}
+
+	if req.GetBody != nil {
+		return req.GetBody, false, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}, true, nil
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
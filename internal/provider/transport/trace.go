@@ -0,0 +1,258 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/apierrors"
+)
+
+// TraceSubsystem is the tflog subsystem HTTP traces are written to. Its level
+// follows TF_LOG_PROVIDER_CACHEFLY unless TF_LOG_PROVIDER_CACHEFLY_HTTP is set.
+const TraceSubsystem = "http"
+
+// traceLevelEnvVars are checked in order for the level of the HTTP trace. The
+// first one that is set decides whether requests are traced at all.
+var traceLevelEnvVars = []string{"TF_LOG_PROVIDER_CACHEFLY_HTTP", "TF_LOG_PROVIDER_CACHEFLY", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// maxTracedBodySize caps how much of a request or response body is logged.
+const maxTracedBodySize = 64 * 1024
+
+const redactedValue = "***REDACTED***"
+
+// redactedKeys lists the fields whose values never appear in traces. Keys are
+// compared after lowercasing and removing "_" and "-", so both api_token and
+// apiToken match.
+var redactedKeys = map[string]bool{
+	"apitoken":       true,
+	"secretkey":      true,
+	"accesskey":      true,
+	"password":       true,
+	"jsonkey":        true,
+	"apikey":         true,
+	"certificatekey": true,
+	"token":          true,
+}
+
+// redactedPairPattern matches key/value pairs in bodies that are not valid
+// JSON, such as form data or truncated JSON documents.
+var redactedPairPattern = regexp.MustCompile(`(?i)("?(?:api[_-]?token|secret[_-]?key|access[_-]?key|password|json[_-]?key|api[_-]?key|certificate[_-]?key|token)"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|[^&,\s}]+)`)
+
+// traceTransport logs every request and response to the HTTP subsystem with
+// credentials removed.
+type traceTransport struct {
+	next http.RoundTripper
+
+	// subsystem adds the HTTP subsystem logger to a request context. Its
+	// options are built once; the logger itself has to be attached to each
+	// request's context.
+	subsystem func(context.Context) context.Context
+}
+
+// NewTraceTransport wraps next so that each request is logged at TRACE level
+// with its method, path, status, latency, request ID and bodies. The log level
+// is read from the environment once: when TRACE is not enabled, requests are
+// passed through without reading their bodies.
+func NewTraceTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if !traceEnabled() {
+		return next
+	}
+
+	level := tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CACHEFLY", TraceSubsystem)
+
+	return &traceTransport{
+		next: next,
+		subsystem: func(ctx context.Context) context.Context {
+			return tflog.NewSubsystem(ctx, TraceSubsystem, level)
+		},
+	}
+}
+
+// traceEnabled reports whether the environment enables TRACE logging for the
+// HTTP subsystem. TF_LOG=JSON logs at TRACE level as well.
+func traceEnabled() bool {
+	for _, name := range traceLevelEnvVars {
+		if value := os.Getenv(name); value != "" {
+			level := strings.ToUpper(value)
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.subsystem(req.Context())
+
+	requestBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   redactedPath(req.URL),
+	}
+	if len(requestBody) > 0 {
+		fields["request_body"] = redactBody(requestBody)
+	}
+	tflog.SubsystemTrace(ctx, TraceSubsystem, "Sending CacheFly API request", fields)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	delete(fields, "request_body")
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemTrace(ctx, TraceSubsystem, "CacheFly API request failed", fields)
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if requestID := apierrors.RequestID(resp.Header, nil); requestID != "" {
+		fields["request_id"] = requestID
+	}
+
+	responseBody, err := peekResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(responseBody) > 0 {
+		fields["response_body"] = redactBody(responseBody)
+	}
+	tflog.SubsystemTrace(ctx, TraceSubsystem, "Received CacheFly API response", fields)
+
+	return resp, nil
+}
+
+// peekRequestBody reads the request body and replaces it with a copy so the
+// next transport still sees the full body.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	return data, nil
+}
+
+// peekResponseBody reads the response body and replaces it with a copy for
+// the caller.
+func peekResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactedPath returns the request path and query with credential
+// parameters removed.
+func redactedPath(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Path
+	}
+
+	query := u.Query()
+	for key := range query {
+		if isRedactedKey(key) {
+			query.Set(key, redactedValue)
+		}
+	}
+
+	return u.Path + "?" + query.Encode()
+}
+
+// redactBody returns body as a string with the values of credential fields
+// replaced. JSON bodies are rewritten field by field; anything else falls
+// back to pattern matching.
+func redactBody(body []byte) string {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var out string
+	if err := decoder.Decode(&doc); err == nil {
+		redacted, err := json.Marshal(redactValue(doc))
+		if err == nil {
+			out = string(redacted)
+		}
+	}
+	if out == "" {
+		out = redactedPairPattern.ReplaceAllString(string(body), `${1}"`+redactedValue+`"`)
+	}
+
+	if len(out) > maxTracedBodySize {
+		out = out[:maxTracedBodySize] + "...(truncated)"
+	}
+
+	return out
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isRedactedKey(key) {
+				if item != nil {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isRedactedKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return redactedKeys[normalized]
+}
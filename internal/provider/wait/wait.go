@@ -0,0 +1,88 @@
+// Package wait polls for a condition with exponential backoff until it holds
+// or a timeout expires.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Backoff controls the delay between polls.
+type Backoff struct {
+	// Initial is the delay before the second poll.
+	Initial time.Duration
+
+	// Max caps the delay between polls.
+	Max time.Duration
+}
+
+// DefaultBackoff starts at two seconds and doubles up to thirty seconds.
+var DefaultBackoff = Backoff{Initial: 2 * time.Second, Max: 30 * time.Second}
+
+// Condition reports whether the awaited state has been reached. The progress
+// string describes what is still pending and is used in the timeout error.
+type Condition func(ctx context.Context) (done bool, progress string, err error)
+
+// TimeoutError is returned when the condition does not hold in time.
+type TimeoutError struct {
+	Timeout  time.Duration
+	Attempts int
+
+	// Progress is the last progress reported by the condition.
+	Progress string
+}
+
+func (e *TimeoutError) Error() string {
+	message := fmt.Sprintf("timed out after %s (%d attempts)", e.Timeout, e.Attempts)
+	if e.Progress != "" {
+		message += ": " + e.Progress
+	}
+	return message
+}
+
+// Until polls condition until it reports done, it returns an error, ctx is
+// cancelled or timeout expires. onPoll, if set, is called after every poll
+// that is not done, for progress logging.
+func Until(ctx context.Context, timeout time.Duration, backoff Backoff, condition Condition, onPoll func(attempt int, progress string)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delay := backoff.Initial
+	var progress string
+
+	for attempt := 1; ; attempt++ {
+		done, current, err := condition(ctx)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &TimeoutError{Timeout: timeout, Attempts: attempt, Progress: progress}
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+		progress = current
+
+		if onPoll != nil {
+			onPoll(attempt, progress)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &TimeoutError{Timeout: timeout, Attempts: attempt, Progress: progress}
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > backoff.Max {
+			delay = backoff.Max
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cachefly/terraform-provider-cachefly/internal/provider/wait"
+)
+
+var testBackoff = wait.Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+func TestUntil_Done(t *testing.T) {
+	var attempts []int
+
+	calls := 0
+	err := wait.Until(context.Background(), time.Second, testBackoff, func(ctx context.Context) (bool, string, error) {
+		calls++
+		return calls == 3, fmt.Sprintf("call %d", calls), nil
+	}, func(attempt int, progress string) {
+		attempts = append(attempts, attempt)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, attempts)
+}
+
+func TestUntil_Timeout(t *testing.T) {
+	err := wait.Until(context.Background(), 20*time.Millisecond, testBackoff, func(ctx context.Context) (bool, string, error) {
+		return false, "status is DEPLOYING", nil
+	}, nil)
+
+	var timeoutErr *wait.TimeoutError
+	if assert.ErrorAs(t, err, &timeoutErr) {
+		assert.Equal(t, "status is DEPLOYING", timeoutErr.Progress)
+		assert.Greater(t, timeoutErr.Attempts, 1)
+		assert.Contains(t, err.Error(), "timed out after 20ms")
+	}
+}
+
+func TestUntil_Error(t *testing.T) {
+	want := errors.New("API error 500: internal error")
+
+	err := wait.Until(context.Background(), time.Second, testBackoff, func(ctx context.Context) (bool, string, error) {
+		return false, "", want
+	}, nil)
+
+	assert.ErrorIs(t, err, want)
+}